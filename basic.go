@@ -4,6 +4,12 @@
 package protocol
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/segmentio/encoding/json"
+
 	"go.lsp.dev/uri"
 )
 
@@ -25,7 +31,8 @@ var EOL = []string{"\n", "\r\n", "\r"}
 
 // Position represents a text document expressed as zero-based line and zero-based character offset.
 //
-// The offsets are based on a UTF-16 string representation.
+// The offsets are based on a UTF-16 string representation unless another PositionEncodingKind
+// was negotiated during initialization.
 // So a string of the form "a𐐀b" the character offset of the character "a" is 0,
 // the character offset of "𐐀" is 1 and the character offset of "b" is 3 since 𐐀 is represented using two code
 // units in UTF-16.
@@ -53,6 +60,31 @@ type Position struct {
 	Character uint32 `json:"character"`
 }
 
+// PositionEncodingKind is a type indicating how positions are encoded,
+// specifically what column offsets mean.
+//
+// @since 3.17.0.
+type PositionEncodingKind string
+
+// list of PositionEncodingKind.
+const (
+	// PositionEncodingKindUTF8 character offsets count UTF-8 code units (e.g bytes).
+	PositionEncodingKindUTF8 PositionEncodingKind = "utf-8"
+
+	// PositionEncodingKindUTF16 character offsets count UTF-16 code units.
+	//
+	// This is the default and must always be supported
+	// by servers.
+	PositionEncodingKindUTF16 PositionEncodingKind = "utf-16"
+
+	// PositionEncodingKindUTF32 character offsets count UTF-32 code units.
+	//
+	// Implementation note: these are the same as Unicode code points,
+	// so this PositionEncodingKind may also be used for an
+	// encoding-agnostic representation of character offsets.
+	PositionEncodingKindUTF32 PositionEncodingKind = "utf-32"
+)
+
 // Range represents a text document expressed as (zero-based) start and end positions.
 //
 // A range is comparable to a selection in an editor. Therefore the end position is exclusive.
@@ -66,6 +98,18 @@ type Range struct {
 	End Position `json:"end"`
 }
 
+// comparePosition returns -1, 0 or +1 depending on whether a is before, equal to or after b.
+func comparePosition(a, b Position) int {
+	switch {
+	case a.Line < b.Line, a.Line == b.Line && a.Character < b.Character:
+		return -1
+	case a.Line == b.Line && a.Character == b.Character:
+		return 0
+	default:
+		return 1
+	}
+}
+
 // Location represents a location inside a resource, such as a line inside a text file.
 type Location struct {
 	URI   DocumentURI `json:"uri"`
@@ -97,6 +141,109 @@ type LocationLink struct {
 	TargetSelectionRange Range `json:"targetSelectionRange"`
 }
 
+// LocationResult is the result of the "textDocument/declaration", "textDocument/definition",
+// "textDocument/typeDefinition" and "textDocument/implementation" requests.
+//
+// At most one of Location, Locations or LocationLinks is set. The LocationLinks form depends on
+// the "linkSupport" client capability of the request. A LocationResult with no field set is
+// encoded as null.
+type LocationResult struct {
+	Location      *Location
+	Locations     []Location
+	LocationLinks []LocationLink
+}
+
+// compile time check whether the LocationResult implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*LocationResult)(nil)
+	_ json.Unmarshaler = (*LocationResult)(nil)
+)
+
+// NewLocationResult returns the LocationResult of links for a client with or without linkSupport.
+//
+// The links are converted to Locations using their TargetSelectionRange if linkSupport is false.
+func NewLocationResult(links []LocationLink, linkSupport bool) *LocationResult {
+	if linkSupport {
+		return &LocationResult{LocationLinks: links}
+	}
+
+	locations := make([]Location, len(links))
+	for i, link := range links {
+		locations[i] = Location{
+			URI:   link.TargetURI,
+			Range: link.TargetSelectionRange,
+		}
+	}
+
+	return &LocationResult{Locations: locations}
+}
+
+// Links returns the result as LocationLinks, converting Locations if needed.
+func (r *LocationResult) Links() []LocationLink {
+	switch {
+	case r.LocationLinks != nil:
+		return r.LocationLinks
+	case r.Location != nil:
+		return []LocationLink{locationToLink(*r.Location)}
+	case r.Locations != nil:
+		links := make([]LocationLink, len(r.Locations))
+		for i, location := range r.Locations {
+			links[i] = locationToLink(location)
+		}
+		return links
+	default:
+		return nil
+	}
+}
+
+func locationToLink(location Location) LocationLink {
+	return LocationLink{
+		TargetURI:            location.URI,
+		TargetRange:          location.Range,
+		TargetSelectionRange: location.Range,
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r LocationResult) MarshalJSON() ([]byte, error) {
+	switch {
+	case r.Location != nil:
+		return json.Marshal(r.Location)
+	case r.Locations != nil:
+		return json.Marshal(r.Locations)
+	case r.LocationLinks != nil:
+		return json.Marshal(r.LocationLinks)
+	default:
+		return []byte("null"), nil
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *LocationResult) UnmarshalJSON(data []byte) error {
+	*r = LocationResult{}
+
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '{':
+		return json.Unmarshal(data, &r.Location)
+	}
+
+	var probe []struct {
+		TargetURI json.RawMessage `json:"targetUri"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+
+	if len(probe) > 0 && probe[0].TargetURI != nil {
+		return json.Unmarshal(data, &r.LocationLinks)
+	}
+
+	return json.Unmarshal(data, &r.Locations)
+}
+
 // Command represents a reference to a command. Provides a title which will be used to represent a command in the UI.
 //
 // Commands are identified by a string identifier.
@@ -178,7 +325,91 @@ type TextDocumentEdit struct {
 	//
 	// @since 3.16.0 - support for AnnotatedTextEdit.
 	// This is guarded by the client capability Workspace.WorkspaceEdit.ChangeAnnotationSupport.
-	Edits []TextEdit `json:"edits"` // []TextEdit | []AnnotatedTextEdit
+	Edits []TextDocumentEditItem `json:"edits"` // []TextEdit | []AnnotatedTextEdit
+}
+
+// TextEdits returns the edits to be applied without their change annotations.
+func (e *TextDocumentEdit) TextEdits() []TextEdit {
+	if e.Edits == nil {
+		return nil
+	}
+
+	edits := make([]TextEdit, len(e.Edits))
+	for i, edit := range e.Edits {
+		edits[i] = edit.Edit()
+	}
+
+	return edits
+}
+
+// TextDocumentEditItem is an element of the TextDocumentEdit.Edits.
+//
+// Exactly one of TextEdit or AnnotatedTextEdit is set. The variant is selected by the
+// "annotationId" property.
+//
+// @since 3.16.0.
+type TextDocumentEditItem struct {
+	TextEdit          *TextEdit
+	AnnotatedTextEdit *AnnotatedTextEdit
+}
+
+// compile time check whether the TextDocumentEditItem implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*TextDocumentEditItem)(nil)
+	_ json.Unmarshaler = (*TextDocumentEditItem)(nil)
+)
+
+var errEmptyTextDocumentEditItem = errors.New("empty TextDocumentEditItem")
+
+// Edit returns the text edit, without its change annotation if any.
+func (i TextDocumentEditItem) Edit() TextEdit {
+	switch {
+	case i.TextEdit != nil:
+		return *i.TextEdit
+	case i.AnnotatedTextEdit != nil:
+		return i.AnnotatedTextEdit.TextEdit
+	default:
+		return TextEdit{}
+	}
+}
+
+// AnnotationID returns the change annotation identifier, and reports whether the edit is annotated.
+func (i TextDocumentEditItem) AnnotationID() (ChangeAnnotationIdentifier, bool) {
+	if i.AnnotatedTextEdit == nil {
+		return "", false
+	}
+
+	return i.AnnotatedTextEdit.AnnotationID, true
+}
+
+// MarshalJSON implements json.Marshaler.
+func (i TextDocumentEditItem) MarshalJSON() ([]byte, error) {
+	switch {
+	case i.TextEdit != nil:
+		return json.Marshal(i.TextEdit)
+	case i.AnnotatedTextEdit != nil:
+		return json.Marshal(i.AnnotatedTextEdit)
+	default:
+		return nil, errEmptyTextDocumentEditItem
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *TextDocumentEditItem) UnmarshalJSON(data []byte) error {
+	*i = TextDocumentEditItem{}
+
+	var probe struct {
+		AnnotationID *ChangeAnnotationIdentifier `json:"annotationId"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+
+	if probe.AnnotationID != nil {
+		return json.Unmarshal(data, &i.AnnotatedTextEdit)
+	}
+
+	return json.Unmarshal(data, &i.TextEdit)
 }
 
 // ResourceOperationKind is the file event type.
@@ -276,6 +507,80 @@ type DeleteFile struct {
 	AnnotationID ChangeAnnotationIdentifier `json:"annotationId,omitempty"`
 }
 
+// DocumentChange is an element of the WorkspaceEdit.DocumentChanges.
+//
+// Exactly one of TextDocumentEdit, CreateFile, RenameFile or DeleteFile is set. The variant is
+// selected by the "kind" property, which TextDocumentEdit doesn't have.
+type DocumentChange struct {
+	TextDocumentEdit *TextDocumentEdit
+	CreateFile       *CreateFile
+	RenameFile       *RenameFile
+	DeleteFile       *DeleteFile
+}
+
+// compile time check whether the DocumentChange implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*DocumentChange)(nil)
+	_ json.Unmarshaler = (*DocumentChange)(nil)
+)
+
+var errEmptyDocumentChange = errors.New("empty DocumentChange")
+
+// MarshalJSON implements json.Marshaler.
+//
+// The Kind of a resource operation defaults to the operation's kind if empty.
+func (c DocumentChange) MarshalJSON() ([]byte, error) {
+	switch {
+	case c.TextDocumentEdit != nil:
+		return json.Marshal(c.TextDocumentEdit)
+	case c.CreateFile != nil:
+		op := *c.CreateFile
+		if op.Kind == "" {
+			op.Kind = CreateResourceOperation
+		}
+		return json.Marshal(op)
+	case c.RenameFile != nil:
+		op := *c.RenameFile
+		if op.Kind == "" {
+			op.Kind = RenameResourceOperation
+		}
+		return json.Marshal(op)
+	case c.DeleteFile != nil:
+		op := *c.DeleteFile
+		if op.Kind == "" {
+			op.Kind = DeleteResourceOperation
+		}
+		return json.Marshal(op)
+	default:
+		return nil, errEmptyDocumentChange
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *DocumentChange) UnmarshalJSON(data []byte) error {
+	*c = DocumentChange{}
+
+	var probe struct {
+		Kind ResourceOperationKind `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+
+	switch probe.Kind {
+	case "":
+		return json.Unmarshal(data, &c.TextDocumentEdit)
+	case CreateResourceOperation:
+		return json.Unmarshal(data, &c.CreateFile)
+	case RenameResourceOperation:
+		return json.Unmarshal(data, &c.RenameFile)
+	case DeleteResourceOperation:
+		return json.Unmarshal(data, &c.DeleteFile)
+	default:
+		return fmt.Errorf("unknown DocumentChange kind: %q", probe.Kind)
+	}
+}
+
 // WorkspaceEdit represent a changes to many resources managed in the workspace.
 //
 // The edit should either provide changes or documentChanges.
@@ -295,7 +600,7 @@ type WorkspaceEdit struct {
 	//
 	// If a client neither supports `documentChanges` nor `workspace.workspaceEdit.resourceOperations` then
 	// only plain `TextEdit`s using the `changes` property are supported.
-	DocumentChanges []TextDocumentEdit `json:"documentChanges,omitempty"`
+	DocumentChanges []DocumentChange `json:"documentChanges,omitempty"`
 
 	// ChangeAnnotations is a map of change annotations that can be referenced in
 	// "AnnotatedTextEdit"s or create, rename and delete file / folder
@@ -308,6 +613,52 @@ type WorkspaceEdit struct {
 	ChangeAnnotations map[ChangeAnnotationIdentifier]ChangeAnnotation `json:"changeAnnotations,omitempty"`
 }
 
+// ValidateChangeAnnotations reports an error if a text edit or a resource operation of the
+// DocumentChanges refers to a change annotation that is missing from ChangeAnnotations.
+//
+// @since 3.16.0.
+func (e *WorkspaceEdit) ValidateChangeAnnotations() error {
+	check := func(id ChangeAnnotationIdentifier, i int) error {
+		if id == "" {
+			return nil
+		}
+		if _, ok := e.ChangeAnnotations[id]; !ok {
+			return fmt.Errorf("documentChanges[%d]: unknown change annotation %q", i, id)
+		}
+		return nil
+	}
+
+	for i, change := range e.DocumentChanges {
+		var err error
+		switch {
+		case change.TextDocumentEdit != nil:
+			for _, edit := range change.TextDocumentEdit.Edits {
+				id, ok := edit.AnnotationID()
+				if !ok {
+					continue
+				}
+				if id == "" {
+					return fmt.Errorf("documentChanges[%d]: empty change annotation identifier", i)
+				}
+				if err := check(id, i); err != nil {
+					return err
+				}
+			}
+		case change.CreateFile != nil:
+			err = check(change.CreateFile.AnnotationID, i)
+		case change.RenameFile != nil:
+			err = check(change.RenameFile.AnnotationID, i)
+		case change.DeleteFile != nil:
+			err = check(change.DeleteFile.AnnotationID, i)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // TextDocumentIdentifier indicates the using a URI. On the protocol level, URIs are passed as strings.
 type TextDocumentIdentifier struct {
 	// URI is the text document's URI.
@@ -703,3 +1054,67 @@ type MarkupContent struct {
 	// Value is the content itself
 	Value string `json:"value"`
 }
+
+// Documentation is a human-readable doc-comment, which is either a plain string or a MarkupContent.
+//
+// Exactly one of StringValue or MarkupContent is set.
+type Documentation struct {
+	StringValue   *string
+	MarkupContent *MarkupContent
+}
+
+// compile time check whether the Documentation implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*Documentation)(nil)
+	_ json.Unmarshaler = (*Documentation)(nil)
+)
+
+var errEmptyDocumentation = errors.New("documentation is neither a string nor a markup content")
+
+// NewDocumentation returns the plain string Documentation of doc.
+func NewDocumentation(doc string) *Documentation {
+	return &Documentation{StringValue: &doc}
+}
+
+// NewMarkupDocumentation returns the MarkupContent Documentation of value interpreted as kind.
+func NewMarkupDocumentation(kind MarkupKind, value string) *Documentation {
+	return &Documentation{MarkupContent: &MarkupContent{Kind: kind, Value: value}}
+}
+
+// String implements fmt.Stringer.
+//
+// It returns the doc-comment without its markup kind.
+func (d Documentation) String() string {
+	switch {
+	case d.StringValue != nil:
+		return *d.StringValue
+	case d.MarkupContent != nil:
+		return d.MarkupContent.Value
+	default:
+		return ""
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Documentation) MarshalJSON() ([]byte, error) {
+	switch {
+	case d.StringValue != nil:
+		return json.Marshal(d.StringValue)
+	case d.MarkupContent != nil:
+		return json.Marshal(d.MarkupContent)
+	default:
+		return nil, errEmptyDocumentation
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Documentation) UnmarshalJSON(data []byte) error {
+	*d = Documentation{}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		return json.Unmarshal(data, &d.MarkupContent)
+	}
+
+	return json.Unmarshal(data, &d.StringValue)
+}
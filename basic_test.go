@@ -468,6 +468,244 @@ func TestLocationLink(t *testing.T) {
 	})
 }
 
+func TestLocationResult(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantLocation      = `{"uri":"file:///path/to/basic.go","range":{"start":{"line":25,"character":1},"end":{"line":25,"character":5}}}`
+		wantLocations     = `[{"uri":"file:///path/to/basic.go","range":{"start":{"line":25,"character":1},"end":{"line":25,"character":5}}}]`
+		wantLocationLinks = `[{"originSelectionRange":{"start":{"line":10,"character":2},"end":{"line":10,"character":6}},"targetUri":"file:///path/to/basic.go","targetRange":{"start":{"line":24,"character":0},"end":{"line":27,"character":1}},"targetSelectionRange":{"start":{"line":25,"character":1},"end":{"line":25,"character":5}}}]`
+		wantEmpty         = `[]`
+		wantNil           = `null`
+	)
+	selectionRange := Range{
+		Start: Position{Line: 25, Character: 1},
+		End:   Position{Line: 25, Character: 5},
+	}
+	location := Location{
+		URI:   uri.File("/path/to/basic.go"),
+		Range: selectionRange,
+	}
+	links := []LocationLink{
+		{
+			OriginSelectionRange: &Range{
+				Start: Position{Line: 10, Character: 2},
+				End:   Position{Line: 10, Character: 6},
+			},
+			TargetURI: uri.File("/path/to/basic.go"),
+			TargetRange: Range{
+				Start: Position{Line: 24, Character: 0},
+				End:   Position{Line: 27, Character: 1},
+			},
+			TargetSelectionRange: selectionRange,
+		},
+	}
+	wantTypeLocation := LocationResult{Location: &location}
+	wantTypeLocations := LocationResult{Locations: []Location{location}}
+	wantTypeLocationLinks := LocationResult{LocationLinks: links}
+	wantTypeEmpty := LocationResult{Locations: []Location{}}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          LocationResult
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Location",
+				field:          wantTypeLocation,
+				want:           wantLocation,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Locations",
+				field:          wantTypeLocations,
+				want:           wantLocations,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "LocationLinks",
+				field:          wantTypeLocationLinks,
+				want:           wantLocationLinks,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Empty",
+				field:          wantTypeEmpty,
+				want:           wantEmpty,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Nil",
+				field:          LocationResult{},
+				want:           wantNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantTypeLocations,
+				want:           wantLocation,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             LocationResult
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Location",
+				field:            wantLocation,
+				want:             wantTypeLocation,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Locations",
+				field:            wantLocations,
+				want:             wantTypeLocations,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "LocationLinks",
+				field:            wantLocationLinks,
+				want:             wantTypeLocationLinks,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Empty",
+				field:            wantEmpty,
+				want:             wantTypeEmpty,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Nil",
+				field:            wantNil,
+				want:             LocationResult{},
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantLocation,
+				want:             wantTypeLocations,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got LocationResult
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("LinkSupport", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name string
+			caps *DefinitionTextDocumentClientCapabilities
+			want *LocationResult
+		}{
+			{
+				name: "NilCapabilities",
+				caps: nil,
+				want: &wantTypeLocations,
+			},
+			{
+				name: "NoLinkSupport",
+				caps: &DefinitionTextDocumentClientCapabilities{},
+				want: &wantTypeLocations,
+			},
+			{
+				name: "LinkSupport",
+				caps: &DefinitionTextDocumentClientCapabilities{LinkSupport: true},
+				want: &wantTypeLocationLinks,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				if diff := cmp.Diff(tt.want, tt.caps.LocationResult(links)); diff != "" {
+					t.Errorf("%s: (-want +got)\n%s", tt.name, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Links", func(t *testing.T) {
+		t.Parallel()
+
+		want := []LocationLink{
+			{
+				TargetURI:            uri.File("/path/to/basic.go"),
+				TargetRange:          selectionRange,
+				TargetSelectionRange: selectionRange,
+			},
+		}
+		if diff := cmp.Diff(want, wantTypeLocation.Links()); diff != "" {
+			t.Errorf("Location: (-want +got)\n%s", diff)
+		}
+		if diff := cmp.Diff(want, wantTypeLocations.Links()); diff != "" {
+			t.Errorf("Locations: (-want +got)\n%s", diff)
+		}
+		if diff := cmp.Diff(links, wantTypeLocationLinks.Links()); diff != "" {
+			t.Errorf("LocationLinks: (-want +got)\n%s", diff)
+		}
+	})
+}
+
 func TestCodeDescription(t *testing.T) {
 	t.Parallel()
 
@@ -1055,19 +1293,21 @@ func TestTextDocumentEdit(t *testing.T) {
 			},
 			Version: NewVersion(int32(10)),
 		},
-		Edits: []TextEdit{
+		Edits: []TextDocumentEditItem{
 			{
-				Range: Range{
-					Start: Position{
-						Line:      25,
-						Character: 1,
-					},
-					End: Position{
-						Line:      27,
-						Character: 3,
+				TextEdit: &TextEdit{
+					Range: Range{
+						Start: Position{
+							Line:      25,
+							Character: 1,
+						},
+						End: Position{
+							Line:      27,
+							Character: 3,
+						},
 					},
+					NewText: "foo bar",
 				},
-				NewText: "foo bar",
 			},
 		},
 	}
@@ -1078,21 +1318,153 @@ func TestTextDocumentEdit(t *testing.T) {
 			},
 			Version: NewVersion(int32(10)),
 		},
-		Edits: []TextEdit{
+		Edits: []TextDocumentEditItem{
 			{
+				TextEdit: &TextEdit{
+					Range: Range{
+						Start: Position{
+							Line:      2,
+							Character: 1,
+						},
+						End: Position{
+							Line:      3,
+							Character: 2,
+						},
+					},
+					NewText: "foo bar",
+				},
+			},
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          TextDocumentEdit
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Valid",
+				field:          wantType,
+				want:           want,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantType,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             TextDocumentEdit
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Valid",
+				field:            want,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            want,
+				want:             wantInvalidType,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got TextDocumentEdit
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+}
+
+func TestTextDocumentEditItem(t *testing.T) {
+	t.Parallel()
+
+	const (
+		want        = `[{"range":{"start":{"line":25,"character":1},"end":{"line":27,"character":3}},"newText":"foo bar"},{"range":{"start":{"line":28,"character":1},"end":{"line":28,"character":3}},"newText":"baz","annotationId":"testAnnotationIdentifier"}]`
+		wantInvalid = `[{"range":{"start":{"line":25,"character":1},"end":{"line":27,"character":3}},"newText":"foo bar"},{"range":{"start":{"line":28,"character":1},"end":{"line":28,"character":3}},"newText":"baz"}]`
+	)
+	wantType := []TextDocumentEditItem{
+		{
+			TextEdit: &TextEdit{
 				Range: Range{
 					Start: Position{
-						Line:      2,
+						Line:      25,
 						Character: 1,
 					},
 					End: Position{
-						Line:      3,
-						Character: 2,
+						Line:      27,
+						Character: 3,
 					},
 				},
 				NewText: "foo bar",
 			},
 		},
+		{
+			AnnotatedTextEdit: &AnnotatedTextEdit{
+				TextEdit: TextEdit{
+					Range: Range{
+						Start: Position{
+							Line:      28,
+							Character: 1,
+						},
+						End: Position{
+							Line:      28,
+							Character: 3,
+						},
+					},
+					NewText: "baz",
+				},
+				AnnotationID: ChangeAnnotationIdentifier("testAnnotationIdentifier"),
+			},
+		},
 	}
 
 	t.Run("Marshal", func(t *testing.T) {
@@ -1100,7 +1472,7 @@ func TestTextDocumentEdit(t *testing.T) {
 
 		tests := []struct {
 			name           string
-			field          TextDocumentEdit
+			field          []TextDocumentEditItem
 			want           string
 			wantMarshalErr bool
 			wantErr        bool
@@ -1119,6 +1491,13 @@ func TestTextDocumentEdit(t *testing.T) {
 				wantMarshalErr: false,
 				wantErr:        true,
 			},
+			{
+				name:           "InvalidEmpty",
+				field:          []TextDocumentEditItem{{}},
+				want:           "",
+				wantMarshalErr: true,
+				wantErr:        false,
+			},
 		}
 
 		for _, tt := range tests {
@@ -1144,7 +1523,7 @@ func TestTextDocumentEdit(t *testing.T) {
 		tests := []struct {
 			name             string
 			field            string
-			want             TextDocumentEdit
+			want             []TextDocumentEditItem
 			wantUnmarshalErr bool
 			wantErr          bool
 		}{
@@ -1157,8 +1536,8 @@ func TestTextDocumentEdit(t *testing.T) {
 			},
 			{
 				name:             "Invalid",
-				field:            want,
-				want:             wantInvalidType,
+				field:            wantInvalid,
+				want:             wantType,
 				wantUnmarshalErr: false,
 				wantErr:          true,
 			},
@@ -1169,7 +1548,7 @@ func TestTextDocumentEdit(t *testing.T) {
 			t.Run(tt.name, func(t *testing.T) {
 				t.Parallel()
 
-				var got TextDocumentEdit
+				var got []TextDocumentEditItem
 				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
 					t.Fatal(err)
 				}
@@ -1180,6 +1559,23 @@ func TestTextDocumentEdit(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("Accessors", func(t *testing.T) {
+		t.Parallel()
+
+		edit := TextDocumentEdit{Edits: wantType}
+		wantEdits := []TextEdit{*wantType[0].TextEdit, wantType[1].AnnotatedTextEdit.TextEdit}
+		if diff := cmp.Diff(wantEdits, edit.TextEdits()); diff != "" {
+			t.Errorf("TextEdits: (-want +got)\n%s", diff)
+		}
+
+		if id, ok := wantType[0].AnnotationID(); ok || id != "" {
+			t.Errorf("AnnotationID: got (%q, %t), want (\"\", false)", id, ok)
+		}
+		if id, ok := wantType[1].AnnotationID(); !ok || id != "testAnnotationIdentifier" {
+			t.Errorf("AnnotationID: got (%q, %t), want (\"testAnnotationIdentifier\", true)", id, ok)
+		}
+	})
 }
 
 func TestCreateFileOptions(t *testing.T) {
@@ -1908,18 +2304,193 @@ func TestDeleteFile(t *testing.T) {
 		wantNilOptions = `{"kind":"delete","uri":"file:///path/to/delete.go"}`
 		wantInvalid    = `{"kind":"delete","uri":"file:///path/to/delete2.go","options":{"recursive":false,"ignoreIfNotExists":false},"annotationId":"invalidAnnotationIdentifier"}`
 	)
-	wantType := DeleteFile{
-		Kind: "delete",
-		URI:  uri.File("/path/to/delete.go"),
-		Options: &DeleteFileOptions{
-			Recursive:         true,
-			IgnoreIfNotExists: true,
+	wantType := DeleteFile{
+		Kind: "delete",
+		URI:  uri.File("/path/to/delete.go"),
+		Options: &DeleteFileOptions{
+			Recursive:         true,
+			IgnoreIfNotExists: true,
+		},
+		AnnotationID: ChangeAnnotationIdentifier("testAnnotationIdentifier"),
+	}
+	wantTypeNilOptions := DeleteFile{
+		Kind: "delete",
+		URI:  uri.File("/path/to/delete.go"),
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          DeleteFile
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Valid",
+				field:          wantType,
+				want:           want,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "ValidNilOptions",
+				field:          wantTypeNilOptions,
+				want:           wantNilOptions,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantType,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             DeleteFile
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Valid",
+				field:            want,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "ValidNilOptions",
+				field:            wantNilOptions,
+				want:             wantTypeNilOptions,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got DeleteFile
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+}
+
+func TestDocumentChange(t *testing.T) {
+	t.Parallel()
+
+	const (
+		want        = `[{"textDocument":{"uri":"file:///path/to/basic.go","version":10},"edits":[{"range":{"start":{"line":25,"character":1},"end":{"line":27,"character":3}},"newText":"foo bar"}]},{"kind":"create","uri":"file:///path/to/new.go","options":{"overwrite":true}},{"kind":"rename","oldUri":"file:///path/to/new.go","newUri":"file:///path/to/renamed.go","annotationId":"testAnnotationIdentifier"},{"textDocument":{"uri":"file:///path/to/renamed.go","version":null},"edits":[]},{"kind":"delete","uri":"file:///path/to/basic.go","options":{"recursive":true}}]`
+		wantInvalid = `[{"kind":"create","uri":"file:///path/to/new.go","options":{"overwrite":true}},{"textDocument":{"uri":"file:///path/to/basic.go","version":10},"edits":[{"range":{"start":{"line":25,"character":1},"end":{"line":27,"character":3}},"newText":"foo bar"}]}]`
+		wantUnknown = `[{"kind":"copy","uri":"file:///path/to/basic.go"}]`
+	)
+	wantType := []DocumentChange{
+		{
+			TextDocumentEdit: &TextDocumentEdit{
+				TextDocument: OptionalVersionedTextDocumentIdentifier{
+					TextDocumentIdentifier: TextDocumentIdentifier{
+						URI: uri.File("/path/to/basic.go"),
+					},
+					Version: NewVersion(int32(10)),
+				},
+				Edits: []TextDocumentEditItem{
+					{
+						TextEdit: &TextEdit{
+							Range: Range{
+								Start: Position{
+									Line:      25,
+									Character: 1,
+								},
+								End: Position{
+									Line:      27,
+									Character: 3,
+								},
+							},
+							NewText: "foo bar",
+						},
+					},
+				},
+			},
+		},
+		{
+			CreateFile: &CreateFile{
+				Kind: CreateResourceOperation,
+				URI:  uri.File("/path/to/new.go"),
+				Options: &CreateFileOptions{
+					Overwrite: true,
+				},
+			},
+		},
+		{
+			RenameFile: &RenameFile{
+				Kind:         RenameResourceOperation,
+				OldURI:       uri.File("/path/to/new.go"),
+				NewURI:       uri.File("/path/to/renamed.go"),
+				AnnotationID: ChangeAnnotationIdentifier("testAnnotationIdentifier"),
+			},
+		},
+		{
+			TextDocumentEdit: &TextDocumentEdit{
+				TextDocument: OptionalVersionedTextDocumentIdentifier{
+					TextDocumentIdentifier: TextDocumentIdentifier{
+						URI: uri.File("/path/to/renamed.go"),
+					},
+				},
+				Edits: []TextDocumentEditItem{},
+			},
+		},
+		{
+			DeleteFile: &DeleteFile{
+				Kind: DeleteResourceOperation,
+				URI:  uri.File("/path/to/basic.go"),
+				Options: &DeleteFileOptions{
+					Recursive: true,
+				},
+			},
 		},
-		AnnotationID: ChangeAnnotationIdentifier("testAnnotationIdentifier"),
-	}
-	wantTypeNilOptions := DeleteFile{
-		Kind: "delete",
-		URI:  uri.File("/path/to/delete.go"),
 	}
 
 	t.Run("Marshal", func(t *testing.T) {
@@ -1927,7 +2498,7 @@ func TestDeleteFile(t *testing.T) {
 
 		tests := []struct {
 			name           string
-			field          DeleteFile
+			field          []DocumentChange
 			want           string
 			wantMarshalErr bool
 			wantErr        bool
@@ -1939,13 +2510,6 @@ func TestDeleteFile(t *testing.T) {
 				wantMarshalErr: false,
 				wantErr:        false,
 			},
-			{
-				name:           "ValidNilOptions",
-				field:          wantTypeNilOptions,
-				want:           wantNilOptions,
-				wantMarshalErr: false,
-				wantErr:        false,
-			},
 			{
 				name:           "Invalid",
 				field:          wantType,
@@ -1953,6 +2517,13 @@ func TestDeleteFile(t *testing.T) {
 				wantMarshalErr: false,
 				wantErr:        true,
 			},
+			{
+				name:           "InvalidEmpty",
+				field:          []DocumentChange{{}},
+				want:           "",
+				wantMarshalErr: true,
+				wantErr:        false,
+			},
 		}
 
 		for _, tt := range tests {
@@ -1978,7 +2549,7 @@ func TestDeleteFile(t *testing.T) {
 		tests := []struct {
 			name             string
 			field            string
-			want             DeleteFile
+			want             []DocumentChange
 			wantUnmarshalErr bool
 			wantErr          bool
 		}{
@@ -1989,13 +2560,6 @@ func TestDeleteFile(t *testing.T) {
 				wantUnmarshalErr: false,
 				wantErr:          false,
 			},
-			{
-				name:             "ValidNilOptions",
-				field:            wantNilOptions,
-				want:             wantTypeNilOptions,
-				wantUnmarshalErr: false,
-				wantErr:          false,
-			},
 			{
 				name:             "Invalid",
 				field:            wantInvalid,
@@ -2003,6 +2567,13 @@ func TestDeleteFile(t *testing.T) {
 				wantUnmarshalErr: false,
 				wantErr:          true,
 			},
+			{
+				name:             "InvalidUnknownKind",
+				field:            wantUnknown,
+				want:             nil,
+				wantUnmarshalErr: true,
+				wantErr:          true,
+			},
 		}
 
 		for _, tt := range tests {
@@ -2010,7 +2581,7 @@ func TestDeleteFile(t *testing.T) {
 			t.Run(tt.name, func(t *testing.T) {
 				t.Parallel()
 
-				var got DeleteFile
+				var got []DocumentChange
 				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
 					t.Fatal(err)
 				}
@@ -2021,6 +2592,39 @@ func TestDeleteFile(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		t.Parallel()
+
+		var got []DocumentChange
+		if err := json.Unmarshal([]byte(want), &got); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := json.Marshal(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(want, string(data)); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+	})
+
+	t.Run("DefaultKind", func(t *testing.T) {
+		t.Parallel()
+
+		const want = `{"kind":"delete","uri":"file:///path/to/basic.go"}`
+
+		got, err := json.Marshal(DocumentChange{DeleteFile: &DeleteFile{URI: uri.File("/path/to/basic.go")}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(want, string(got)); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+	})
 }
 
 func TestWorkspaceEdit(t *testing.T) {
@@ -2050,27 +2654,31 @@ func TestWorkspaceEdit(t *testing.T) {
 				},
 			},
 		},
-		DocumentChanges: []TextDocumentEdit{
+		DocumentChanges: []DocumentChange{
 			{
-				TextDocument: OptionalVersionedTextDocumentIdentifier{
-					TextDocumentIdentifier: TextDocumentIdentifier{
-						URI: uri.File("/path/to/basic.go"),
+				TextDocumentEdit: &TextDocumentEdit{
+					TextDocument: OptionalVersionedTextDocumentIdentifier{
+						TextDocumentIdentifier: TextDocumentIdentifier{
+							URI: uri.File("/path/to/basic.go"),
+						},
+						Version: NewVersion(int32(10)),
 					},
-					Version: NewVersion(int32(10)),
-				},
-				Edits: []TextEdit{
-					{
-						Range: Range{
-							Start: Position{
-								Line:      25,
-								Character: 1,
-							},
-							End: Position{
-								Line:      27,
-								Character: 3,
+					Edits: []TextDocumentEditItem{
+						{
+							TextEdit: &TextEdit{
+								Range: Range{
+									Start: Position{
+										Line:      25,
+										Character: 1,
+									},
+									End: Position{
+										Line:      27,
+										Character: 3,
+									},
+								},
+								NewText: "foo bar",
 							},
 						},
-						NewText: "foo bar",
 					},
 				},
 			},
@@ -2084,27 +2692,31 @@ func TestWorkspaceEdit(t *testing.T) {
 		},
 	}
 	wantTypeNilChanges := WorkspaceEdit{
-		DocumentChanges: []TextDocumentEdit{
+		DocumentChanges: []DocumentChange{
 			{
-				TextDocument: OptionalVersionedTextDocumentIdentifier{
-					TextDocumentIdentifier: TextDocumentIdentifier{
-						URI: uri.File("/path/to/basic.go"),
+				TextDocumentEdit: &TextDocumentEdit{
+					TextDocument: OptionalVersionedTextDocumentIdentifier{
+						TextDocumentIdentifier: TextDocumentIdentifier{
+							URI: uri.File("/path/to/basic.go"),
+						},
+						Version: NewVersion(int32(10)),
 					},
-					Version: NewVersion(int32(10)),
-				},
-				Edits: []TextEdit{
-					{
-						Range: Range{
-							Start: Position{
-								Line:      25,
-								Character: 1,
-							},
-							End: Position{
-								Line:      27,
-								Character: 3,
+					Edits: []TextDocumentEditItem{
+						{
+							TextEdit: &TextEdit{
+								Range: Range{
+									Start: Position{
+										Line:      25,
+										Character: 1,
+									},
+									End: Position{
+										Line:      27,
+										Character: 3,
+									},
+								},
+								NewText: "foo bar",
 							},
 						},
-						NewText: "foo bar",
 					},
 				},
 			},
@@ -2245,6 +2857,111 @@ func TestWorkspaceEdit(t *testing.T) {
 	})
 }
 
+func TestWorkspaceEdit_ValidateChangeAnnotations(t *testing.T) {
+	t.Parallel()
+
+	annotations := map[ChangeAnnotationIdentifier]ChangeAnnotation{
+		"testAnnotationIdentifier": {
+			Label: "testLabel",
+		},
+	}
+	annotatedEdit := func(id ChangeAnnotationIdentifier) DocumentChange {
+		return DocumentChange{
+			TextDocumentEdit: &TextDocumentEdit{
+				TextDocument: OptionalVersionedTextDocumentIdentifier{
+					TextDocumentIdentifier: TextDocumentIdentifier{
+						URI: uri.File("/path/to/basic.go"),
+					},
+				},
+				Edits: []TextDocumentEditItem{
+					{
+						TextEdit: &TextEdit{NewText: "foo"},
+					},
+					{
+						AnnotatedTextEdit: &AnnotatedTextEdit{
+							TextEdit:     TextEdit{NewText: "bar"},
+							AnnotationID: id,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		edit    WorkspaceEdit
+		wantErr bool
+	}{
+		{
+			name: "Valid",
+			edit: WorkspaceEdit{
+				DocumentChanges: []DocumentChange{
+					annotatedEdit("testAnnotationIdentifier"),
+					{
+						RenameFile: &RenameFile{
+							OldURI:       uri.File("/path/to/basic.go"),
+							NewURI:       uri.File("/path/to/renamed.go"),
+							AnnotationID: "testAnnotationIdentifier",
+						},
+					},
+					{
+						DeleteFile: &DeleteFile{URI: uri.File("/path/to/renamed.go")},
+					},
+				},
+				ChangeAnnotations: annotations,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "ValidEmpty",
+			edit:    WorkspaceEdit{},
+			wantErr: false,
+		},
+		{
+			name: "UnknownTextEditAnnotation",
+			edit: WorkspaceEdit{
+				DocumentChanges:   []DocumentChange{annotatedEdit("unknown")},
+				ChangeAnnotations: annotations,
+			},
+			wantErr: true,
+		},
+		{
+			name: "EmptyTextEditAnnotation",
+			edit: WorkspaceEdit{
+				DocumentChanges:   []DocumentChange{annotatedEdit("")},
+				ChangeAnnotations: annotations,
+			},
+			wantErr: true,
+		},
+		{
+			name: "UnknownCreateFileAnnotation",
+			edit: WorkspaceEdit{
+				DocumentChanges: []DocumentChange{
+					{
+						CreateFile: &CreateFile{
+							URI:          uri.File("/path/to/new.go"),
+							AnnotationID: "testAnnotationIdentifier",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.edit.ValidateChangeAnnotations(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateChangeAnnotations() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestTextDocumentIdentifier(t *testing.T) {
 	t.Parallel()
 
@@ -3211,3 +3928,132 @@ func TestMarkupContent(t *testing.T) {
 		}
 	})
 }
+
+func TestDocumentation(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantString        = `"test documentation"`
+		wantMarkupContent = `{"kind":"markdown","value":"test documentation"}`
+	)
+	wantTypeString := *NewDocumentation("test documentation")
+	wantTypeMarkupContent := *NewMarkupDocumentation(Markdown, "test documentation")
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          Documentation
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "String",
+				field:          wantTypeString,
+				want:           wantString,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "MarkupContent",
+				field:          wantTypeMarkupContent,
+				want:           wantMarkupContent,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantTypeString,
+				want:           wantMarkupContent,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+			{
+				name:           "InvalidEmpty",
+				field:          Documentation{},
+				want:           "",
+				wantMarshalErr: true,
+				wantErr:        false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             Documentation
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "String",
+				field:            wantString,
+				want:             wantTypeString,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "MarkupContent",
+				field:            wantMarkupContent,
+				want:             wantTypeMarkupContent,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantMarkupContent,
+				want:             wantTypeString,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got Documentation
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+
+		if got := wantTypeString.String(); got != "test documentation" {
+			t.Errorf("String() = %q, want %q", got, "test documentation")
+		}
+		if got := wantTypeMarkupContent.String(); got != "test documentation" {
+			t.Errorf("String() = %q, want %q", got, "test documentation")
+		}
+	})
+}
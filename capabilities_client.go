@@ -31,6 +31,11 @@ type ClientCapabilities struct {
 	// @since 3.16.0.
 	General *GeneralClientCapabilities `json:"general,omitempty"`
 
+	// NotebookDocument capabilities specific to the notebook document support.
+	//
+	// @since 3.17.0.
+	NotebookDocument *NotebookDocumentClientCapabilities `json:"notebookDocument,omitempty"`
+
 	// Experimental client capabilities.
 	Experimental interface{} `json:"experimental,omitempty"`
 }
@@ -82,6 +87,21 @@ type WorkspaceClientCapabilities struct {
 	//
 	// @since 3.16.0.
 	FileOperations *WorkspaceClientCapabilitiesFileOperations `json:"fileOperations,omitempty"`
+
+	// InlayHint is the client workspace capabilities specific to inlay hints.
+	//
+	// @since 3.17.0.
+	InlayHint *InlayHintWorkspaceClientCapabilities `json:"inlayHint,omitempty"`
+
+	// Diagnostics is the client workspace capabilities specific to diagnostics.
+	//
+	// @since 3.17.0.
+	Diagnostics *DiagnosticWorkspaceClientCapabilities `json:"diagnostics,omitempty"`
+
+	// InlineValue is the client workspace capabilities specific to inline values.
+	//
+	// @since 3.17.0.
+	InlineValue *InlineValueWorkspaceClientCapabilities `json:"inlineValue,omitempty"`
 }
 
 // WorkspaceClientCapabilitiesWorkspaceEdit capabilities specific to "WorkspaceEdit"s.
@@ -178,6 +198,22 @@ type WorkspaceSymbolClientCapabilities struct {
 	//
 	// @since 3.16.0
 	TagSupport *TagSupportCapabilities `json:"tagSupport,omitempty"`
+
+	// ResolveSupport is the client support partial workspace symbols. The client will send the
+	// request "workspaceSymbol/resolve" to the server to resolve additional
+	// properties.
+	//
+	// @since 3.17.0.
+	ResolveSupport *WorkspaceSymbolClientCapabilitiesResolveSupport `json:"resolveSupport,omitempty"`
+}
+
+// WorkspaceSymbolClientCapabilitiesResolveSupport ResolveSupport in the WorkspaceSymbolClientCapabilities.
+//
+// @since 3.17.0.
+type WorkspaceSymbolClientCapabilitiesResolveSupport struct {
+	// Properties is the properties that a client can resolve lazily. Usually
+	// "location.range".
+	Properties []string `json:"properties"`
 }
 
 type SymbolKindCapabilities struct {
@@ -258,6 +294,48 @@ type WorkspaceClientCapabilitiesFileOperations struct {
 	WillDelete bool `json:"willDelete,omitempty"`
 }
 
+// InlayHintWorkspaceClientCapabilities client workspace capabilities specific to inlay hints.
+//
+// @since 3.17.0.
+type InlayHintWorkspaceClientCapabilities struct {
+	// RefreshSupport whether the client implementation supports a refresh request sent from
+	// the server to the client.
+	//
+	// Note that this event is global and will force the client to refresh all
+	// inlay hints currently shown. It should be used with absolute care and
+	// is useful for situation where a server for example detects a project wide
+	// change that requires such a calculation.
+	RefreshSupport bool `json:"refreshSupport,omitempty"`
+}
+
+// DiagnosticWorkspaceClientCapabilities workspace client capabilities specific to diagnostic pull requests.
+//
+// @since 3.17.0.
+type DiagnosticWorkspaceClientCapabilities struct {
+	// RefreshSupport whether the client implementation supports a refresh request sent from
+	// the server to the client.
+	//
+	// Note that this event is global and will force the client to refresh all
+	// pulled diagnostics currently shown. It should be used with absolute care
+	// and is useful for situation where a server for example detects a project
+	// wide change that requires such a calculation.
+	RefreshSupport bool `json:"refreshSupport,omitempty"`
+}
+
+// InlineValueWorkspaceClientCapabilities client workspace capabilities specific to inline values.
+//
+// @since 3.17.0.
+type InlineValueWorkspaceClientCapabilities struct {
+	// RefreshSupport whether the client implementation supports a refresh request sent from
+	// the server to the client.
+	//
+	// Note that this event is global and will force the client to refresh all
+	// inline values currently shown. It should be used with absolute care and
+	// is useful for situation where a server for example detect a project wide
+	// change that requires such a calculation.
+	RefreshSupport bool `json:"refreshSupport,omitempty"`
+}
+
 // TextDocumentClientCapabilities Text document specific client capabilities.
 type TextDocumentClientCapabilities struct {
 	// Synchronization defines which synchronization capabilities the client supports.
@@ -358,6 +436,26 @@ type TextDocumentClientCapabilities struct {
 	//
 	// @since 3.16.0.
 	Moniker *MonikerClientCapabilities `json:"moniker,omitempty"`
+
+	// InlayHint capabilities specific to the "textDocument/inlayHint" request.
+	//
+	// @since 3.17.0.
+	InlayHint *InlayHintClientCapabilities `json:"inlayHint,omitempty"`
+
+	// Diagnostic capabilities specific to the diagnostic pull model.
+	//
+	// @since 3.17.0.
+	Diagnostic *DiagnosticClientCapabilities `json:"diagnostic,omitempty"`
+
+	// TypeHierarchy capabilities specific to the various type hierarchy requests.
+	//
+	// @since 3.17.0.
+	TypeHierarchy *TypeHierarchyClientCapabilities `json:"typeHierarchy,omitempty"`
+
+	// InlineValue capabilities specific to the "textDocument/inlineValue" request.
+	//
+	// @since 3.17.0.
+	InlineValue *InlineValueClientCapabilities `json:"inlineValue,omitempty"`
 }
 
 // TextDocumentSyncClientCapabilities defines which synchronization capabilities the client supports.
@@ -391,6 +489,18 @@ type CompletionTextDocumentClientCapabilities struct {
 	// ContextSupport is the client supports to send additional context information for a
 	// `textDocument/completion` request.
 	ContextSupport bool `json:"contextSupport,omitempty"`
+
+	// InsertTextMode is the client's default when the completion item doesn't provide a
+	// "insertTextMode" property.
+	//
+	// @since 3.17.0.
+	InsertTextMode InsertTextMode `json:"insertTextMode,omitempty"`
+
+	// CompletionList is the client supports the following "CompletionList" specific
+	// capabilities.
+	//
+	// @since 3.17.0.
+	CompletionList *CompletionTextDocumentClientCapabilitiesList `json:"completionList,omitempty"`
 }
 
 // CompletionTextDocumentClientCapabilitiesItem is the client supports the following "CompletionItem" specific
@@ -445,6 +555,12 @@ type CompletionTextDocumentClientCapabilitiesItem struct {
 	//
 	// @since 3.16.0.
 	InsertTextModeSupport *CompletionTextDocumentClientCapabilitiesItemInsertTextModeSupport `json:"insertTextModeSupport,omitempty"`
+
+	// LabelDetailsSupport is the client has support for completion item label
+	// details (see also "CompletionItemLabelDetails").
+	//
+	// @since 3.17.0.
+	LabelDetailsSupport bool `json:"labelDetailsSupport,omitempty"`
 }
 
 // CompletionTextDocumentClientCapabilitiesItemTagSupport specific capabilities for the "TagSupport" in the "textDocument/completion" request.
@@ -475,6 +591,19 @@ type CompletionTextDocumentClientCapabilitiesItemInsertTextModeSupport struct {
 	ValueSet []InsertTextMode `json:"valueSet,omitempty"`
 }
 
+// CompletionTextDocumentClientCapabilitiesList specific capabilities for the "CompletionList" in the "textDocument/completion" request.
+//
+// @since 3.17.0.
+type CompletionTextDocumentClientCapabilitiesList struct {
+	// ItemDefaults is the client supports the following itemDefaults on
+	// a completion list.
+	//
+	// The value lists the supported property names of the
+	// "CompletionList.itemDefaults" object. If omitted
+	// no properties are supported.
+	ItemDefaults []string `json:"itemDefaults,omitempty"`
+}
+
 // CompletionTextDocumentClientCapabilitiesItemKind specific capabilities for the "CompletionItemKind" in the "textDocument/completion" request.
 type CompletionTextDocumentClientCapabilitiesItemKind struct {
 	// The completion item kind values the client supports. When this
@@ -555,6 +684,11 @@ type DeclarationTextDocumentClientCapabilities struct {
 	LinkSupport bool `json:"linkSupport,omitempty"`
 }
 
+// LocationResult returns the "textDocument/declaration" result of links in the form supported by the client.
+func (c *DeclarationTextDocumentClientCapabilities) LocationResult(links []LocationLink) *LocationResult {
+	return NewLocationResult(links, c != nil && c.LinkSupport)
+}
+
 // DefinitionTextDocumentClientCapabilities capabilities specific to the "textDocument/definition".
 //
 // @since 3.14.0.
@@ -566,6 +700,11 @@ type DefinitionTextDocumentClientCapabilities struct {
 	LinkSupport bool `json:"linkSupport,omitempty"`
 }
 
+// LocationResult returns the "textDocument/definition" result of links in the form supported by the client.
+func (c *DefinitionTextDocumentClientCapabilities) LocationResult(links []LocationLink) *LocationResult {
+	return NewLocationResult(links, c != nil && c.LinkSupport)
+}
+
 // TypeDefinitionTextDocumentClientCapabilities capabilities specific to the "textDocument/typeDefinition".
 //
 // @since 3.6.0.
@@ -581,6 +720,11 @@ type TypeDefinitionTextDocumentClientCapabilities struct {
 	LinkSupport bool `json:"linkSupport,omitempty"`
 }
 
+// LocationResult returns the "textDocument/typeDefinition" result of links in the form supported by the client.
+func (c *TypeDefinitionTextDocumentClientCapabilities) LocationResult(links []LocationLink) *LocationResult {
+	return NewLocationResult(links, c != nil && c.LinkSupport)
+}
+
 // ImplementationTextDocumentClientCapabilities capabilities specific to the "textDocument/implementation".
 //
 // @since 3.6.0.
@@ -596,6 +740,11 @@ type ImplementationTextDocumentClientCapabilities struct {
 	LinkSupport bool `json:"linkSupport,omitempty"`
 }
 
+// LocationResult returns the "textDocument/implementation" result of links in the form supported by the client.
+func (c *ImplementationTextDocumentClientCapabilities) LocationResult(links []LocationLink) *LocationResult {
+	return NewLocationResult(links, c != nil && c.LinkSupport)
+}
+
 // ReferencesTextDocumentClientCapabilities capabilities specific to the "textDocument/references".
 type ReferencesTextDocumentClientCapabilities struct {
 	// DynamicRegistration whether references supports dynamic registration.
@@ -633,6 +782,11 @@ type DocumentSymbolClientCapabilities struct {
 	LabelSupport bool `json:"labelSupport,omitempty"`
 }
 
+// DocumentSymbolResult returns the "textDocument/documentSymbol" result of the symbols of uri in the form supported by the client.
+func (c *DocumentSymbolClientCapabilities) DocumentSymbolResult(uri DocumentURI, symbols []DocumentSymbol) *DocumentSymbolResult {
+	return NewDocumentSymbolResult(uri, symbols, c != nil && c.HierarchicalDocumentSymbolSupport)
+}
+
 // DocumentSymbolClientCapabilitiesTagSupport TagSupport in the DocumentSymbolClientCapabilities.
 //
 // @since 3.16.0.
@@ -954,6 +1108,82 @@ type MonikerClientCapabilities struct {
 	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
 }
 
+// InlayHintClientCapabilities capabilities specific to the "textDocument/inlayHint" request.
+//
+// @since 3.17.0.
+type InlayHintClientCapabilities struct {
+	// DynamicRegistration whether inlay hints support dynamic registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// ResolveSupport indicates which properties a client can resolve lazily on an inlay
+	// hint.
+	ResolveSupport *InlayHintClientCapabilitiesResolveSupport `json:"resolveSupport,omitempty"`
+}
+
+// InlayHintClientCapabilitiesResolveSupport ResolveSupport in the InlayHintClientCapabilities.
+//
+// @since 3.17.0.
+type InlayHintClientCapabilitiesResolveSupport struct {
+	// Properties is the properties that a client can resolve lazily.
+	Properties []string `json:"properties"`
+}
+
+// DiagnosticClientCapabilities client capabilities specific to diagnostic pull requests.
+//
+// @since 3.17.0.
+type DiagnosticClientCapabilities struct {
+	// DynamicRegistration whether implementation supports dynamic registration. If this is set to
+	// `true` the client supports the new `(TextDocumentRegistrationOptions &
+	// StaticRegistrationOptions)` return value for the corresponding server
+	// capability as well.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// RelatedDocumentSupport whether the clients supports related documents for document diagnostic pulls.
+	RelatedDocumentSupport bool `json:"relatedDocumentSupport,omitempty"`
+}
+
+// TypeHierarchyClientCapabilities capabilities specific to "textDocument/prepareTypeHierarchy" requests.
+//
+// @since 3.17.0.
+type TypeHierarchyClientCapabilities struct {
+	// DynamicRegistration whether implementation supports dynamic registration. If this is set to
+	// `true` the client supports the new `(TextDocumentRegistrationOptions &
+	// StaticRegistrationOptions)` return value for the corresponding server
+	// capability as well.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// InlineValueClientCapabilities client capabilities specific to inline values.
+//
+// @since 3.17.0.
+type InlineValueClientCapabilities struct {
+	// DynamicRegistration whether implementation supports dynamic registration for inline
+	// value providers.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// NotebookDocumentClientCapabilities capabilities specific to the notebook document support.
+//
+// @since 3.17.0.
+type NotebookDocumentClientCapabilities struct {
+	// Synchronization capabilities specific to notebook document synchronization.
+	Synchronization NotebookDocumentSyncClientCapabilities `json:"synchronization"`
+}
+
+// NotebookDocumentSyncClientCapabilities notebook specific client capabilities.
+//
+// @since 3.17.0.
+type NotebookDocumentSyncClientCapabilities struct {
+	// DynamicRegistration whether implementation supports dynamic registration. If this is
+	// set to `true` the client supports the new
+	// `(NotebookDocumentSyncRegistrationOptions & NotebookDocumentSyncOptions)`
+	// return value for the corresponding server capability as well.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// ExecutionSummarySupport is the client supports sending execution summary data per cell.
+	ExecutionSummarySupport bool `json:"executionSummarySupport,omitempty"`
+}
+
 // WindowClientCapabilities represents a WindowClientCapabilities specific client capabilities.
 //
 // @since 3.15.0.
@@ -1015,6 +1245,26 @@ type GeneralClientCapabilities struct {
 	//
 	// @since 3.16.0.
 	Markdown *MarkdownClientCapabilities `json:"markdown,omitempty"`
+
+	// PositionEncodings is the position encodings supported by the client. Client and server
+	// have to agree on the same position encoding to ensure that offsets
+	// (e.g. character position in a line) are interpreted the same on both
+	// side.
+	//
+	// To keep the protocol backwards compatible the following applies: if
+	// the value "utf-16" is missing from the array of position encodings
+	// servers can assume that the client supports UTF-16. UTF-16 is
+	// therefore a mandatory encoding.
+	//
+	// If omitted it defaults to ["utf-16"].
+	//
+	// Implementation considerations: since the conversion from one encoding
+	// into another requires the content of the file / line the conversion
+	// is best done where the file is read which is usually on the server
+	// side.
+	//
+	// @since 3.17.0.
+	PositionEncodings []PositionEncodingKind `json:"positionEncodings,omitempty"`
 }
 
 // RegularExpressionsClientCapabilities represents a client capabilities specific to regular expressions.
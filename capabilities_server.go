@@ -9,17 +9,28 @@ import (
 
 // ServerCapabilities efines the capabilities provided by a language server.
 type ServerCapabilities struct {
+	// PositionEncoding is the position encoding the server picked from the encodings offered
+	// by the client via the client capability "general.positionEncodings".
+	//
+	// If the client didn't provide any position encodings the only valid
+	// value that a server can return is "utf-16".
+	//
+	// If omitted it defaults to "utf-16".
+	//
+	// @since 3.17.0.
+	PositionEncoding PositionEncodingKind `json:"positionEncoding,omitempty"`
+
 	// TextDocumentSync defines how text documents are synced. Is either a detailed structure defining each notification
 	// or for backwards compatibility the TextDocumentSyncKind number.
 	//
 	// If omitted it defaults to TextDocumentSyncKind.None`
-	TextDocumentSync interface{} `json:"textDocumentSync,omitempty"` // *TextDocumentSyncOptions | TextDocumentSyncKind
+	TextDocumentSync *TextDocumentSync `json:"textDocumentSync,omitempty"`
 
 	// CompletionProvider is The server provides completion support.
 	CompletionProvider *CompletionOptions `json:"completionProvider,omitempty"`
 
 	// HoverProvider is the server provides hover support.
-	HoverProvider interface{} `json:"hoverProvider,omitempty"` // TODO(zchee): bool | *HoverOptions
+	HoverProvider *HoverProvider `json:"hoverProvider,omitempty"`
 
 	// SignatureHelpProvider is the server provides signature help support.
 	SignatureHelpProvider *SignatureHelpOptions `json:"signatureHelpProvider,omitempty"`
@@ -27,35 +38,35 @@ type ServerCapabilities struct {
 	// DeclarationProvider is the server provides Goto Declaration support.
 	//
 	// @since 3.14.0.
-	DeclarationProvider interface{} `json:"declarationProvider,omitempty"` // TODO(zchee): bool | *DeclarationOptions | *DeclarationRegistrationOptions
+	DeclarationProvider *DeclarationProvider `json:"declarationProvider,omitempty"`
 
 	// DefinitionProvider is the server provides Goto definition support.
-	DefinitionProvider interface{} `json:"definitionProvider,omitempty"` // TODO(zchee): bool | *DefinitionOptions
+	DefinitionProvider *DefinitionProvider `json:"definitionProvider,omitempty"`
 
 	// TypeDefinitionProvider is the provides Goto Type Definition support.
 	//
 	// @since 3.6.0.
-	TypeDefinitionProvider interface{} `json:"typeDefinitionProvider,omitempty"` // TODO(zchee): bool | *TypeDefinitionOptions | *TypeDefinitionRegistrationOptions
+	TypeDefinitionProvider *TypeDefinitionProvider `json:"typeDefinitionProvider,omitempty"`
 
 	// ImplementationProvider is the provides Goto Implementation support.
 	//
 	// @since 3.6.0.
-	ImplementationProvider interface{} `json:"implementationProvider,omitempty"` // TODO(zchee): bool | *ImplementationOptions | *ImplementationRegistrationOptions
+	ImplementationProvider *ImplementationProvider `json:"implementationProvider,omitempty"`
 
 	// ReferencesProvider is the server provides find references support.
-	ReferencesProvider interface{} `json:"referencesProvider,omitempty"` // TODO(zchee): bool | *ReferenceOptions
+	ReferencesProvider *ReferencesProvider `json:"referencesProvider,omitempty"`
 
 	// DocumentHighlightProvider is the server provides document highlight support.
-	DocumentHighlightProvider interface{} `json:"documentHighlightProvider,omitempty"` // TODO(zchee): bool | *DocumentHighlightOptions
+	DocumentHighlightProvider *DocumentHighlightProvider `json:"documentHighlightProvider,omitempty"`
 
 	// DocumentSymbolProvider is the server provides document symbol support.
-	DocumentSymbolProvider interface{} `json:"documentSymbolProvider,omitempty"` // TODO(zchee): bool | *DocumentSymbolOptions
+	DocumentSymbolProvider *DocumentSymbolProvider `json:"documentSymbolProvider,omitempty"`
 
 	// CodeActionProvider is the server provides code actions.
 	//
 	// CodeActionOptions may only be specified if the client states that it supports CodeActionLiteralSupport in its
 	// initial Initialize request.
-	CodeActionProvider interface{} `json:"codeActionProvider,omitempty"` // TODO(zchee): bool | *CodeActionOptions
+	CodeActionProvider *CodeActionProvider `json:"codeActionProvider,omitempty"`
 
 	// CodeLensProvider is the server provides code lens.
 	CodeLensProvider *CodeLensOptions `json:"codeLensProvider,omitempty"`
@@ -66,16 +77,16 @@ type ServerCapabilities struct {
 	// ColorProvider is the server provides color provider support.
 	//
 	// @since 3.6.0.
-	ColorProvider interface{} `json:"colorProvider,omitempty"` // TODO(zchee): bool | *DocumentColorOptions | *DocumentColorRegistrationOptions
+	ColorProvider *ColorProvider `json:"colorProvider,omitempty"`
 
 	// WorkspaceSymbolProvider is the server provides workspace symbol support.
-	WorkspaceSymbolProvider interface{} `json:"workspaceSymbolProvider,omitempty"` // TODO(zchee): bool | *WorkspaceSymbolOptions
+	WorkspaceSymbolProvider *WorkspaceSymbolProvider `json:"workspaceSymbolProvider,omitempty"`
 
 	// DocumentFormattingProvider is the server provides document formatting.
-	DocumentFormattingProvider interface{} `json:"documentFormattingProvider,omitempty"` // TODO(zchee): bool | *DocumentFormattingOptions
+	DocumentFormattingProvider *DocumentFormattingProvider `json:"documentFormattingProvider,omitempty"`
 
 	// DocumentRangeFormattingProvider is the server provides document range formatting.
-	DocumentRangeFormattingProvider interface{} `json:"documentRangeFormattingProvider,omitempty"` // TODO(zchee): bool | *DocumentRangeFormattingOptions
+	DocumentRangeFormattingProvider *DocumentRangeFormattingProvider `json:"documentRangeFormattingProvider,omitempty"`
 
 	// DocumentOnTypeFormattingProvider is the server provides document formatting on typing.
 	DocumentOnTypeFormattingProvider *DocumentOnTypeFormattingOptions `json:"documentOnTypeFormattingProvider,omitempty"`
@@ -84,17 +95,17 @@ type ServerCapabilities struct {
 	//
 	// RenameOptions may only be specified if the client states that it supports PrepareSupport in its
 	// initial Initialize request.
-	RenameProvider interface{} `json:"renameProvider,omitempty"` // TODO(zchee): bool | *RenameOptions
+	RenameProvider *RenameProvider `json:"renameProvider,omitempty"`
 
 	// FoldingRangeProvider is the server provides folding provider support.
 	//
 	// @since 3.10.0.
-	FoldingRangeProvider interface{} `json:"foldingRangeProvider,omitempty"` // TODO(zchee): bool | *FoldingRangeOptions | *FoldingRangeRegistrationOptions
+	FoldingRangeProvider *FoldingRangeProvider `json:"foldingRangeProvider,omitempty"`
 
 	// SelectionRangeProvider is the server provides selection range support.
 	//
 	// @since 3.15.0.
-	SelectionRangeProvider interface{} `json:"selectionRangeProvider,omitempty"` // TODO(zchee): bool | *SelectionRangeOptions | *SelectionRangeRegistrationOptions
+	SelectionRangeProvider *SelectionRangeProvider `json:"selectionRangeProvider,omitempty"`
 
 	// ExecuteCommandProvider is the server provides execute command support.
 	ExecuteCommandProvider *ExecuteCommandOptions `json:"executeCommandProvider,omitempty"`
@@ -102,17 +113,17 @@ type ServerCapabilities struct {
 	// CallHierarchyProvider is the server provides call hierarchy support.
 	//
 	// @since 3.16.0.
-	CallHierarchyProvider interface{} `json:"callHierarchyProvider,omitempty"` // TODO(zchee): bool | *CallHierarchyOptions | *CallHierarchyRegistrationOptions
+	CallHierarchyProvider *CallHierarchyProvider `json:"callHierarchyProvider,omitempty"`
 
 	// LinkedEditingRangeProvider is the server provides linked editing range support.
 	//
 	// @since 3.16.0.
-	LinkedEditingRangeProvider interface{} `json:"linkedEditingRangeProvider,omitempty"` // TODO(zchee): bool | *LinkedEditingRangeOptions | *LinkedEditingRangeRegistrationOptions
+	LinkedEditingRangeProvider *LinkedEditingRangeProvider `json:"linkedEditingRangeProvider,omitempty"`
 
 	// SemanticTokensProvider is the server provides semantic tokens support.
 	//
 	// @since 3.16.0.
-	SemanticTokensProvider interface{} `json:"semanticTokensProvider,omitempty"` // TODO(zchee): *SemanticTokensOptions | *SemanticTokensRegistrationOptions
+	SemanticTokensProvider *SemanticTokensProvider `json:"semanticTokensProvider,omitempty"`
 
 	// Workspace is the window specific server capabilities.
 	Workspace *ServerCapabilitiesWorkspace `json:"workspace,omitempty"`
@@ -120,7 +131,32 @@ type ServerCapabilities struct {
 	// MonikerProvider is the server provides moniker support.
 	//
 	// @since 3.16.0.
-	MonikerProvider interface{} `json:"monikerProvider,omitempty"` // TODO(zchee): bool | *MonikerOptions | *MonikerRegistrationOptions
+	MonikerProvider *MonikerProvider `json:"monikerProvider,omitempty"`
+
+	// InlayHintProvider is the server provides inlay hints.
+	//
+	// @since 3.17.0.
+	InlayHintProvider *InlayHintProvider `json:"inlayHintProvider,omitempty"`
+
+	// DiagnosticProvider is the server has support for pull model diagnostics.
+	//
+	// @since 3.17.0.
+	DiagnosticProvider *DiagnosticProvider `json:"diagnosticProvider,omitempty"`
+
+	// TypeHierarchyProvider is the server provides type hierarchy support.
+	//
+	// @since 3.17.0.
+	TypeHierarchyProvider *TypeHierarchyProvider `json:"typeHierarchyProvider,omitempty"`
+
+	// NotebookDocumentSync defines how notebook documents are synced.
+	//
+	// @since 3.17.0.
+	NotebookDocumentSync *NotebookDocumentSync `json:"notebookDocumentSync,omitempty"`
+
+	// InlineValueProvider is the server provides inline values.
+	//
+	// @since 3.17.0.
+	InlineValueProvider *InlineValueProvider `json:"inlineValueProvider,omitempty"`
 
 	// Experimental server capabilities.
 	Experimental interface{} `json:"experimental,omitempty"`
@@ -190,6 +226,22 @@ type CompletionOptions struct {
 
 	// The characters that trigger completion automatically.
 	TriggerCharacters []string `json:"triggerCharacters,omitempty"`
+
+	// CompletionItem is the server supports the following "CompletionItem" specific
+	// capabilities.
+	//
+	// @since 3.17.0.
+	CompletionItem *CompletionOptionsCompletionItem `json:"completionItem,omitempty"`
+}
+
+// CompletionOptionsCompletionItem specific options for the "CompletionItem" in the CompletionOptions.
+//
+// @since 3.17.0.
+type CompletionOptionsCompletionItem struct {
+	// LabelDetailsSupport is the server has support for completion item label
+	// details (see also "CompletionItemLabelDetails") when receiving
+	// a completion item in a resolve call.
+	LabelDetailsSupport bool `json:"labelDetailsSupport,omitempty"`
 }
 
 // HoverOptions option of hover provider server capabilities.
@@ -341,6 +393,12 @@ type DocumentColorRegistrationOptions struct {
 // @since 3.15.0.
 type WorkspaceSymbolOptions struct {
 	WorkDoneProgressOptions
+
+	// ResolveProvider is the server provides support to resolve additional
+	// information for a workspace symbol.
+	//
+	// @since 3.17.0.
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
 }
 
 // DocumentFormattingOptions registration option of DocumentFormatting server capability.
@@ -431,6 +489,16 @@ type LinkedEditingRangeRegistrationOptions struct {
 // @since 3.16.0.
 type SemanticTokensOptions struct {
 	WorkDoneProgressOptions
+
+	// Legend is the legend used by the server.
+	Legend SemanticTokensLegend `json:"legend"`
+
+	// Range is the server supports providing semantic tokens for a specific range
+	// of a document.
+	Range interface{} `json:"range,omitempty"` // bool | {}
+
+	// Full is the server supports providing semantic tokens for a full document.
+	Full interface{} `json:"full,omitempty"` // bool | { delta?: bool }
 }
 
 // SemanticTokensRegistrationOptions registration option of semantic tokens provider server capabilities.
@@ -521,3 +589,137 @@ type MonikerRegistrationOptions struct {
 	TextDocumentRegistrationOptions
 	MonikerOptions
 }
+
+// InlayHintOptions option of inlay hint provider server capabilities.
+//
+// @since 3.17.0.
+type InlayHintOptions struct {
+	WorkDoneProgressOptions
+
+	// ResolveProvider is the server provides support to resolve additional
+	// information for an inlay hint item.
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+}
+
+// InlayHintRegistrationOptions registration option of inlay hint provider server capabilities.
+//
+// @since 3.17.0.
+type InlayHintRegistrationOptions struct {
+	InlayHintOptions
+	TextDocumentRegistrationOptions
+	StaticRegistrationOptions
+}
+
+// DiagnosticOptions option of diagnostic provider server capabilities.
+//
+// @since 3.17.0.
+type DiagnosticOptions struct {
+	WorkDoneProgressOptions
+
+	// Identifier an optional identifier under which the diagnostics are
+	// managed by the client.
+	Identifier string `json:"identifier,omitempty"`
+
+	// InterFileDependencies whether the language has inter file dependencies meaning that
+	// editing code in one file can result in a different diagnostic
+	// set in another file. Inter file dependencies are common for
+	// most programming languages and typically uncommon for linters.
+	InterFileDependencies bool `json:"interFileDependencies"`
+
+	// WorkspaceDiagnostics is the server provides support for workspace diagnostics as well.
+	WorkspaceDiagnostics bool `json:"workspaceDiagnostics"`
+}
+
+// DiagnosticRegistrationOptions registration option of diagnostic provider server capabilities.
+//
+// @since 3.17.0.
+type DiagnosticRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	DiagnosticOptions
+	StaticRegistrationOptions
+}
+
+// TypeHierarchyOptions option of TypeHierarchy.
+//
+// @since 3.17.0.
+type TypeHierarchyOptions struct {
+	WorkDoneProgressOptions
+}
+
+// TypeHierarchyRegistrationOptions registration options of TypeHierarchy.
+//
+// @since 3.17.0.
+type TypeHierarchyRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	TypeHierarchyOptions
+	StaticRegistrationOptions
+}
+
+// NotebookDocumentSyncOptions options specific to a notebook plus its cells
+// to be synced to the server.
+//
+// If a selector provides a notebook document
+// filter but no cell selector all cells of a
+// matching notebook document will be synced.
+//
+// If a selector provides no notebook document
+// filter but only a cell selector all notebook
+// documents that contain at least one matching
+// cell will be synced.
+//
+// @since 3.17.0.
+type NotebookDocumentSyncOptions struct {
+	// NotebookSelector is the notebooks to be synced.
+	NotebookSelector []NotebookDocumentSyncOptionsNotebookSelector `json:"notebookSelector"`
+
+	// Save whether save notification should be forwarded to
+	// the server. Will only be honored if mode === `notebook`.
+	Save bool `json:"save,omitempty"`
+}
+
+// NotebookDocumentSyncOptionsNotebookSelector is the notebook selector of NotebookDocumentSyncOptions.
+//
+// A selector either has a Notebook and optional Cells, or an optional Notebook and Cells.
+//
+// @since 3.17.0.
+type NotebookDocumentSyncOptionsNotebookSelector struct {
+	// Notebook is the notebook to be synced. If a string
+	// value is provided it matches against the
+	// notebook type. '*' matches every notebook.
+	Notebook interface{} `json:"notebook,omitempty"` // string | *NotebookDocumentFilter
+
+	// Cells is the cells of the matching notebook to be synced.
+	Cells []NotebookDocumentSyncOptionsCell `json:"cells,omitempty"`
+}
+
+// NotebookDocumentSyncOptionsCell is the cell selector of NotebookDocumentSyncOptionsNotebookSelector.
+//
+// @since 3.17.0.
+type NotebookDocumentSyncOptionsCell struct {
+	// Language is the language id of the cells to be synced.
+	Language string `json:"language"`
+}
+
+// NotebookDocumentSyncRegistrationOptions registration options of NotebookDocumentSync.
+//
+// @since 3.17.0.
+type NotebookDocumentSyncRegistrationOptions struct {
+	NotebookDocumentSyncOptions
+	StaticRegistrationOptions
+}
+
+// InlineValueOptions option of inline value provider server capabilities.
+//
+// @since 3.17.0.
+type InlineValueOptions struct {
+	WorkDoneProgressOptions
+}
+
+// InlineValueRegistrationOptions registration option of inline value provider server capabilities.
+//
+// @since 3.17.0.
+type InlineValueRegistrationOptions struct {
+	InlineValueOptions
+	TextDocumentRegistrationOptions
+	StaticRegistrationOptions
+}
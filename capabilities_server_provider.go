@@ -0,0 +1,1498 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/segmentio/encoding/json"
+)
+
+var errEmptyProvider = errors.New("provider has neither options nor registration options")
+
+// registrationKind is the type hint providerForms uses to tell the registration options of a provider from its options.
+//
+// The zero registrationKind is used by providers without a registration options form.
+type registrationKind uint8
+
+const (
+	// textDocumentRegistration is used by providers whose registration options embed TextDocumentRegistrationOptions.
+	// An object is decoded as registration options if it has a "documentSelector", which they require, or an "id".
+	textDocumentRegistration registrationKind = iota + 1
+
+	// staticRegistration is used by providers whose registration options only add StaticRegistrationOptions to the
+	// options. As the "id" is optional, an object cannot be told apart from options, and is always decoded as
+	// registration options, which hold everything the options do.
+	staticRegistration
+)
+
+// providerForms points to the fields of a provider of ServerCapabilities holding its bool, options and registration
+// options forms, so that every provider shares the same Enabled, MarshalJSON and UnmarshalJSON.
+type providerForms struct {
+	// value points to the bool form, or is nil if the provider has none.
+	value *bool
+
+	// options points to the pointer to the options.
+	options interface{}
+
+	// registrationOptions points to the pointer to the registration options, or is nil if the provider has none.
+	registrationOptions interface{}
+
+	// registration tells how registration options are detected when decoding.
+	registration registrationKind
+}
+
+// enabled reports whether any form is set, or the bool form is true.
+func (f providerForms) enabled() bool {
+	return (f.value != nil && *f.value) || isSetField(f.options) || isSetField(f.registrationOptions)
+}
+
+// marshal encodes the options if set, else the registration options if set, else the bool form.
+func (f providerForms) marshal() ([]byte, error) {
+	switch {
+	case isSetField(f.options):
+		return json.Marshal(f.options)
+	case isSetField(f.registrationOptions):
+		return json.Marshal(f.registrationOptions)
+	case f.value != nil:
+		return json.Marshal(*f.value)
+	default:
+		return nil, errEmptyProvider
+	}
+}
+
+// unmarshal decodes data into the bool form, the options or the registration options, as told by f.registration.
+func (f providerForms) unmarshal(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && (data[0] == 't' || data[0] == 'f') {
+		if f.value == nil {
+			return fmt.Errorf("unexpected bool provider: %s", data)
+		}
+		return json.Unmarshal(data, f.value)
+	}
+
+	switch f.registration {
+	case textDocumentRegistration:
+		var probe struct {
+			DocumentSelector json.RawMessage `json:"documentSelector"`
+			ID               json.RawMessage `json:"id"`
+		}
+		if err := json.Unmarshal(data, &probe); err != nil {
+			return err
+		}
+		if probe.DocumentSelector != nil || probe.ID != nil {
+			return json.Unmarshal(data, f.registrationOptions)
+		}
+	case staticRegistration:
+		return json.Unmarshal(data, f.registrationOptions)
+	}
+
+	return json.Unmarshal(data, f.options)
+}
+
+// isSetField reports whether field, a pointer to a pointer field, points to a non-nil pointer.
+func isSetField(field interface{}) bool {
+	return field != nil && !reflect.ValueOf(field).Elem().IsNil()
+}
+
+// TextDocumentSync is the TextDocumentSync of ServerCapabilities, which is either a TextDocumentSyncKind
+// or TextDocumentSyncOptions.
+//
+// Kind is used if Options is not set.
+type TextDocumentSync struct {
+	Kind    TextDocumentSyncKind
+	Options *TextDocumentSyncOptions
+}
+
+// compile time check whether the TextDocumentSync implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*TextDocumentSync)(nil)
+	_ json.Unmarshaler = (*TextDocumentSync)(nil)
+)
+
+// Enabled reports whether the server wants text documents to be synced.
+func (p *TextDocumentSync) Enabled() bool {
+	switch {
+	case p == nil:
+		return false
+	case p.Options != nil:
+		o := p.Options
+		return o.OpenClose || o.Change != TextDocumentSyncKindNone || o.WillSave || o.WillSaveWaitUntil || o.Save != nil
+	default:
+		return p.Kind != TextDocumentSyncKindNone
+	}
+}
+
+// TextDocumentSyncKind returns how the text document changes are synced.
+func (p *TextDocumentSync) TextDocumentSyncKind() TextDocumentSyncKind {
+	switch {
+	case p == nil:
+		return TextDocumentSyncKindNone
+	case p.Options != nil:
+		return p.Options.Change
+	default:
+		return p.Kind
+	}
+}
+
+// TextDocumentSyncOptions returns the options of the text document sync.
+//
+// If only a TextDocumentSyncKind is set, the returned options open and close the documents and
+// send the changes of that kind, as the TextDocumentSyncKind form implies.
+func (p *TextDocumentSync) TextDocumentSyncOptions() *TextDocumentSyncOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.Kind == TextDocumentSyncKindNone:
+		return &TextDocumentSyncOptions{}
+	default:
+		return &TextDocumentSyncOptions{
+			OpenClose: true,
+			Change:    p.Kind,
+		}
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p TextDocumentSync) MarshalJSON() ([]byte, error) {
+	if p.Options != nil {
+		return json.Marshal(p.Options)
+	}
+
+	return json.Marshal(p.Kind)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *TextDocumentSync) UnmarshalJSON(data []byte) error {
+	*p = TextDocumentSync{}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		return json.Unmarshal(data, &p.Options)
+	}
+
+	return json.Unmarshal(data, &p.Kind)
+}
+
+// HoverProvider is the HoverProvider of ServerCapabilities, which is either bool or HoverOptions.
+//
+// Value is used if Options is not set.
+type HoverProvider struct {
+	Value   bool
+	Options *HoverOptions
+}
+
+// compile time check whether the HoverProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*HoverProvider)(nil)
+	_ json.Unmarshaler = (*HoverProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *HoverProvider) forms() providerForms {
+	return providerForms{value: &p.Value, options: &p.Options}
+}
+
+// Enabled reports whether the server provides hover support.
+func (p *HoverProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// HoverOptions returns the options of the provider.
+func (p *HoverProvider) HoverOptions() *HoverOptions {
+	if p == nil {
+		return nil
+	}
+
+	return p.Options
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p HoverProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *HoverProvider) UnmarshalJSON(data []byte) error {
+	*p = HoverProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// DeclarationProvider is the DeclarationProvider of ServerCapabilities, which is either bool, DeclarationOptions or DeclarationRegistrationOptions.
+//
+// At most one of Options or RegistrationOptions is set. If neither is set, Value is used.
+//
+// @since 3.14.0.
+type DeclarationProvider struct {
+	Value               bool
+	Options             *DeclarationOptions
+	RegistrationOptions *DeclarationRegistrationOptions
+}
+
+// compile time check whether the DeclarationProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*DeclarationProvider)(nil)
+	_ json.Unmarshaler = (*DeclarationProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *DeclarationProvider) forms() providerForms {
+	return providerForms{
+		value:               &p.Value,
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        textDocumentRegistration,
+	}
+}
+
+// Enabled reports whether the server provides Goto Declaration support.
+func (p *DeclarationProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// DeclarationOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *DeclarationProvider) DeclarationOptions() *DeclarationOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.DeclarationOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p DeclarationProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *DeclarationProvider) UnmarshalJSON(data []byte) error {
+	*p = DeclarationProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// DefinitionProvider is the DefinitionProvider of ServerCapabilities, which is either bool or DefinitionOptions.
+//
+// Value is used if Options is not set.
+type DefinitionProvider struct {
+	Value   bool
+	Options *DefinitionOptions
+}
+
+// compile time check whether the DefinitionProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*DefinitionProvider)(nil)
+	_ json.Unmarshaler = (*DefinitionProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *DefinitionProvider) forms() providerForms {
+	return providerForms{value: &p.Value, options: &p.Options}
+}
+
+// Enabled reports whether the server provides Goto Definition support.
+func (p *DefinitionProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// DefinitionOptions returns the options of the provider.
+func (p *DefinitionProvider) DefinitionOptions() *DefinitionOptions {
+	if p == nil {
+		return nil
+	}
+
+	return p.Options
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p DefinitionProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *DefinitionProvider) UnmarshalJSON(data []byte) error {
+	*p = DefinitionProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// TypeDefinitionProvider is the TypeDefinitionProvider of ServerCapabilities, which is either bool, TypeDefinitionOptions or TypeDefinitionRegistrationOptions.
+//
+// At most one of Options or RegistrationOptions is set. If neither is set, Value is used.
+//
+// @since 3.6.0.
+type TypeDefinitionProvider struct {
+	Value               bool
+	Options             *TypeDefinitionOptions
+	RegistrationOptions *TypeDefinitionRegistrationOptions
+}
+
+// compile time check whether the TypeDefinitionProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*TypeDefinitionProvider)(nil)
+	_ json.Unmarshaler = (*TypeDefinitionProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *TypeDefinitionProvider) forms() providerForms {
+	return providerForms{
+		value:               &p.Value,
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        textDocumentRegistration,
+	}
+}
+
+// Enabled reports whether the server provides Goto Type Definition support.
+func (p *TypeDefinitionProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// TypeDefinitionOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *TypeDefinitionProvider) TypeDefinitionOptions() *TypeDefinitionOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.TypeDefinitionOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p TypeDefinitionProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *TypeDefinitionProvider) UnmarshalJSON(data []byte) error {
+	*p = TypeDefinitionProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// ImplementationProvider is the ImplementationProvider of ServerCapabilities, which is either bool, ImplementationOptions or ImplementationRegistrationOptions.
+//
+// At most one of Options or RegistrationOptions is set. If neither is set, Value is used.
+//
+// @since 3.6.0.
+type ImplementationProvider struct {
+	Value               bool
+	Options             *ImplementationOptions
+	RegistrationOptions *ImplementationRegistrationOptions
+}
+
+// compile time check whether the ImplementationProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*ImplementationProvider)(nil)
+	_ json.Unmarshaler = (*ImplementationProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *ImplementationProvider) forms() providerForms {
+	return providerForms{
+		value:               &p.Value,
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        textDocumentRegistration,
+	}
+}
+
+// Enabled reports whether the server provides Goto Implementation support.
+func (p *ImplementationProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// ImplementationOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *ImplementationProvider) ImplementationOptions() *ImplementationOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.ImplementationOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p ImplementationProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *ImplementationProvider) UnmarshalJSON(data []byte) error {
+	*p = ImplementationProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// ReferencesProvider is the ReferencesProvider of ServerCapabilities, which is either bool or ReferenceOptions.
+//
+// Value is used if Options is not set.
+type ReferencesProvider struct {
+	Value   bool
+	Options *ReferenceOptions
+}
+
+// compile time check whether the ReferencesProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*ReferencesProvider)(nil)
+	_ json.Unmarshaler = (*ReferencesProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *ReferencesProvider) forms() providerForms {
+	return providerForms{value: &p.Value, options: &p.Options}
+}
+
+// Enabled reports whether the server provides find references support.
+func (p *ReferencesProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// ReferenceOptions returns the options of the provider.
+func (p *ReferencesProvider) ReferenceOptions() *ReferenceOptions {
+	if p == nil {
+		return nil
+	}
+
+	return p.Options
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p ReferencesProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *ReferencesProvider) UnmarshalJSON(data []byte) error {
+	*p = ReferencesProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// DocumentHighlightProvider is the DocumentHighlightProvider of ServerCapabilities, which is either bool or DocumentHighlightOptions.
+//
+// Value is used if Options is not set.
+type DocumentHighlightProvider struct {
+	Value   bool
+	Options *DocumentHighlightOptions
+}
+
+// compile time check whether the DocumentHighlightProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*DocumentHighlightProvider)(nil)
+	_ json.Unmarshaler = (*DocumentHighlightProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *DocumentHighlightProvider) forms() providerForms {
+	return providerForms{value: &p.Value, options: &p.Options}
+}
+
+// Enabled reports whether the server provides document highlight support.
+func (p *DocumentHighlightProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// DocumentHighlightOptions returns the options of the provider.
+func (p *DocumentHighlightProvider) DocumentHighlightOptions() *DocumentHighlightOptions {
+	if p == nil {
+		return nil
+	}
+
+	return p.Options
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p DocumentHighlightProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *DocumentHighlightProvider) UnmarshalJSON(data []byte) error {
+	*p = DocumentHighlightProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// DocumentSymbolProvider is the DocumentSymbolProvider of ServerCapabilities, which is either bool or DocumentSymbolOptions.
+//
+// Value is used if Options is not set.
+type DocumentSymbolProvider struct {
+	Value   bool
+	Options *DocumentSymbolOptions
+}
+
+// compile time check whether the DocumentSymbolProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*DocumentSymbolProvider)(nil)
+	_ json.Unmarshaler = (*DocumentSymbolProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *DocumentSymbolProvider) forms() providerForms {
+	return providerForms{value: &p.Value, options: &p.Options}
+}
+
+// Enabled reports whether the server provides document symbol support.
+func (p *DocumentSymbolProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// DocumentSymbolOptions returns the options of the provider.
+func (p *DocumentSymbolProvider) DocumentSymbolOptions() *DocumentSymbolOptions {
+	if p == nil {
+		return nil
+	}
+
+	return p.Options
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p DocumentSymbolProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *DocumentSymbolProvider) UnmarshalJSON(data []byte) error {
+	*p = DocumentSymbolProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// CodeActionProvider is the CodeActionProvider of ServerCapabilities, which is either bool or CodeActionOptions.
+//
+// Value is used if Options is not set.
+type CodeActionProvider struct {
+	Value   bool
+	Options *CodeActionOptions
+}
+
+// compile time check whether the CodeActionProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*CodeActionProvider)(nil)
+	_ json.Unmarshaler = (*CodeActionProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *CodeActionProvider) forms() providerForms {
+	return providerForms{value: &p.Value, options: &p.Options}
+}
+
+// Enabled reports whether the server provides code action support.
+func (p *CodeActionProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// CodeActionOptions returns the options of the provider.
+func (p *CodeActionProvider) CodeActionOptions() *CodeActionOptions {
+	if p == nil {
+		return nil
+	}
+
+	return p.Options
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p CodeActionProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *CodeActionProvider) UnmarshalJSON(data []byte) error {
+	*p = CodeActionProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// ColorProvider is the ColorProvider of ServerCapabilities, which is either bool, DocumentColorOptions or DocumentColorRegistrationOptions.
+//
+// At most one of Options or RegistrationOptions is set. If neither is set, Value is used.
+//
+// @since 3.6.0.
+type ColorProvider struct {
+	Value               bool
+	Options             *DocumentColorOptions
+	RegistrationOptions *DocumentColorRegistrationOptions
+}
+
+// compile time check whether the ColorProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*ColorProvider)(nil)
+	_ json.Unmarshaler = (*ColorProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *ColorProvider) forms() providerForms {
+	return providerForms{
+		value:               &p.Value,
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        textDocumentRegistration,
+	}
+}
+
+// Enabled reports whether the server provides color support.
+func (p *ColorProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// DocumentColorOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *ColorProvider) DocumentColorOptions() *DocumentColorOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.DocumentColorOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p ColorProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *ColorProvider) UnmarshalJSON(data []byte) error {
+	*p = ColorProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// WorkspaceSymbolProvider is the WorkspaceSymbolProvider of ServerCapabilities, which is either bool or WorkspaceSymbolOptions.
+//
+// Value is used if Options is not set.
+type WorkspaceSymbolProvider struct {
+	Value   bool
+	Options *WorkspaceSymbolOptions
+}
+
+// compile time check whether the WorkspaceSymbolProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*WorkspaceSymbolProvider)(nil)
+	_ json.Unmarshaler = (*WorkspaceSymbolProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *WorkspaceSymbolProvider) forms() providerForms {
+	return providerForms{value: &p.Value, options: &p.Options}
+}
+
+// Enabled reports whether the server provides workspace symbol support.
+func (p *WorkspaceSymbolProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// WorkspaceSymbolOptions returns the options of the provider.
+func (p *WorkspaceSymbolProvider) WorkspaceSymbolOptions() *WorkspaceSymbolOptions {
+	if p == nil {
+		return nil
+	}
+
+	return p.Options
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p WorkspaceSymbolProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *WorkspaceSymbolProvider) UnmarshalJSON(data []byte) error {
+	*p = WorkspaceSymbolProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// DocumentFormattingProvider is the DocumentFormattingProvider of ServerCapabilities, which is either bool or DocumentFormattingOptions.
+//
+// Value is used if Options is not set.
+type DocumentFormattingProvider struct {
+	Value   bool
+	Options *DocumentFormattingOptions
+}
+
+// compile time check whether the DocumentFormattingProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*DocumentFormattingProvider)(nil)
+	_ json.Unmarshaler = (*DocumentFormattingProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *DocumentFormattingProvider) forms() providerForms {
+	return providerForms{value: &p.Value, options: &p.Options}
+}
+
+// Enabled reports whether the server provides document formatting support.
+func (p *DocumentFormattingProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// DocumentFormattingOptions returns the options of the provider.
+func (p *DocumentFormattingProvider) DocumentFormattingOptions() *DocumentFormattingOptions {
+	if p == nil {
+		return nil
+	}
+
+	return p.Options
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p DocumentFormattingProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *DocumentFormattingProvider) UnmarshalJSON(data []byte) error {
+	*p = DocumentFormattingProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// DocumentRangeFormattingProvider is the DocumentRangeFormattingProvider of ServerCapabilities, which is either bool or DocumentRangeFormattingOptions.
+//
+// Value is used if Options is not set.
+type DocumentRangeFormattingProvider struct {
+	Value   bool
+	Options *DocumentRangeFormattingOptions
+}
+
+// compile time check whether the DocumentRangeFormattingProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*DocumentRangeFormattingProvider)(nil)
+	_ json.Unmarshaler = (*DocumentRangeFormattingProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *DocumentRangeFormattingProvider) forms() providerForms {
+	return providerForms{value: &p.Value, options: &p.Options}
+}
+
+// Enabled reports whether the server provides document range formatting support.
+func (p *DocumentRangeFormattingProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// DocumentRangeFormattingOptions returns the options of the provider.
+func (p *DocumentRangeFormattingProvider) DocumentRangeFormattingOptions() *DocumentRangeFormattingOptions {
+	if p == nil {
+		return nil
+	}
+
+	return p.Options
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p DocumentRangeFormattingProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *DocumentRangeFormattingProvider) UnmarshalJSON(data []byte) error {
+	*p = DocumentRangeFormattingProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// RenameProvider is the RenameProvider of ServerCapabilities, which is either bool or RenameOptions.
+//
+// Value is used if Options is not set.
+type RenameProvider struct {
+	Value   bool
+	Options *RenameOptions
+}
+
+// compile time check whether the RenameProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*RenameProvider)(nil)
+	_ json.Unmarshaler = (*RenameProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *RenameProvider) forms() providerForms {
+	return providerForms{value: &p.Value, options: &p.Options}
+}
+
+// Enabled reports whether the server provides rename support.
+func (p *RenameProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// RenameOptions returns the options of the provider.
+func (p *RenameProvider) RenameOptions() *RenameOptions {
+	if p == nil {
+		return nil
+	}
+
+	return p.Options
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p RenameProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *RenameProvider) UnmarshalJSON(data []byte) error {
+	*p = RenameProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// FoldingRangeProvider is the FoldingRangeProvider of ServerCapabilities, which is either bool, FoldingRangeOptions or FoldingRangeRegistrationOptions.
+//
+// At most one of Options or RegistrationOptions is set. If neither is set, Value is used.
+//
+// @since 3.10.0.
+type FoldingRangeProvider struct {
+	Value               bool
+	Options             *FoldingRangeOptions
+	RegistrationOptions *FoldingRangeRegistrationOptions
+}
+
+// compile time check whether the FoldingRangeProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*FoldingRangeProvider)(nil)
+	_ json.Unmarshaler = (*FoldingRangeProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *FoldingRangeProvider) forms() providerForms {
+	return providerForms{
+		value:               &p.Value,
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        textDocumentRegistration,
+	}
+}
+
+// Enabled reports whether the server provides folding range support.
+func (p *FoldingRangeProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// FoldingRangeOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *FoldingRangeProvider) FoldingRangeOptions() *FoldingRangeOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.FoldingRangeOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p FoldingRangeProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *FoldingRangeProvider) UnmarshalJSON(data []byte) error {
+	*p = FoldingRangeProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// SelectionRangeProvider is the SelectionRangeProvider of ServerCapabilities, which is either bool, SelectionRangeOptions or SelectionRangeRegistrationOptions.
+//
+// At most one of Options or RegistrationOptions is set. If neither is set, Value is used.
+//
+// @since 3.15.0.
+type SelectionRangeProvider struct {
+	Value               bool
+	Options             *SelectionRangeOptions
+	RegistrationOptions *SelectionRangeRegistrationOptions
+}
+
+// compile time check whether the SelectionRangeProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*SelectionRangeProvider)(nil)
+	_ json.Unmarshaler = (*SelectionRangeProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *SelectionRangeProvider) forms() providerForms {
+	return providerForms{
+		value:               &p.Value,
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        textDocumentRegistration,
+	}
+}
+
+// Enabled reports whether the server provides selection range support.
+func (p *SelectionRangeProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// SelectionRangeOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *SelectionRangeProvider) SelectionRangeOptions() *SelectionRangeOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.SelectionRangeOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p SelectionRangeProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *SelectionRangeProvider) UnmarshalJSON(data []byte) error {
+	*p = SelectionRangeProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// CallHierarchyProvider is the CallHierarchyProvider of ServerCapabilities, which is either bool, CallHierarchyOptions or CallHierarchyRegistrationOptions.
+//
+// At most one of Options or RegistrationOptions is set. If neither is set, Value is used.
+//
+// @since 3.16.0.
+type CallHierarchyProvider struct {
+	Value               bool
+	Options             *CallHierarchyOptions
+	RegistrationOptions *CallHierarchyRegistrationOptions
+}
+
+// compile time check whether the CallHierarchyProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*CallHierarchyProvider)(nil)
+	_ json.Unmarshaler = (*CallHierarchyProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *CallHierarchyProvider) forms() providerForms {
+	return providerForms{
+		value:               &p.Value,
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        textDocumentRegistration,
+	}
+}
+
+// Enabled reports whether the server provides call hierarchy support.
+func (p *CallHierarchyProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// CallHierarchyOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *CallHierarchyProvider) CallHierarchyOptions() *CallHierarchyOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.CallHierarchyOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p CallHierarchyProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *CallHierarchyProvider) UnmarshalJSON(data []byte) error {
+	*p = CallHierarchyProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// LinkedEditingRangeProvider is the LinkedEditingRangeProvider of ServerCapabilities, which is either bool, LinkedEditingRangeOptions or LinkedEditingRangeRegistrationOptions.
+//
+// At most one of Options or RegistrationOptions is set. If neither is set, Value is used.
+//
+// @since 3.16.0.
+type LinkedEditingRangeProvider struct {
+	Value               bool
+	Options             *LinkedEditingRangeOptions
+	RegistrationOptions *LinkedEditingRangeRegistrationOptions
+}
+
+// compile time check whether the LinkedEditingRangeProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*LinkedEditingRangeProvider)(nil)
+	_ json.Unmarshaler = (*LinkedEditingRangeProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *LinkedEditingRangeProvider) forms() providerForms {
+	return providerForms{
+		value:               &p.Value,
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        textDocumentRegistration,
+	}
+}
+
+// Enabled reports whether the server provides linked editing range support.
+func (p *LinkedEditingRangeProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// LinkedEditingRangeOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *LinkedEditingRangeProvider) LinkedEditingRangeOptions() *LinkedEditingRangeOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.LinkedEditingRangeOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p LinkedEditingRangeProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *LinkedEditingRangeProvider) UnmarshalJSON(data []byte) error {
+	*p = LinkedEditingRangeProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// SemanticTokensProvider is the SemanticTokensProvider of ServerCapabilities, which is either SemanticTokensOptions or SemanticTokensRegistrationOptions.
+//
+// Exactly one of Options or RegistrationOptions is set.
+//
+// @since 3.16.0.
+type SemanticTokensProvider struct {
+	Options             *SemanticTokensOptions
+	RegistrationOptions *SemanticTokensRegistrationOptions
+}
+
+// compile time check whether the SemanticTokensProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*SemanticTokensProvider)(nil)
+	_ json.Unmarshaler = (*SemanticTokensProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *SemanticTokensProvider) forms() providerForms {
+	return providerForms{
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        textDocumentRegistration,
+	}
+}
+
+// Enabled reports whether the server provides semantic tokens support.
+func (p *SemanticTokensProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// SemanticTokensOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *SemanticTokensProvider) SemanticTokensOptions() *SemanticTokensOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.SemanticTokensOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p SemanticTokensProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *SemanticTokensProvider) UnmarshalJSON(data []byte) error {
+	*p = SemanticTokensProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// MonikerProvider is the MonikerProvider of ServerCapabilities, which is either bool, MonikerOptions or MonikerRegistrationOptions.
+//
+// At most one of Options or RegistrationOptions is set. If neither is set, Value is used.
+//
+// @since 3.16.0.
+type MonikerProvider struct {
+	Value               bool
+	Options             *MonikerOptions
+	RegistrationOptions *MonikerRegistrationOptions
+}
+
+// compile time check whether the MonikerProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*MonikerProvider)(nil)
+	_ json.Unmarshaler = (*MonikerProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *MonikerProvider) forms() providerForms {
+	return providerForms{
+		value:               &p.Value,
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        textDocumentRegistration,
+	}
+}
+
+// Enabled reports whether the server provides moniker support.
+func (p *MonikerProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// MonikerOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *MonikerProvider) MonikerOptions() *MonikerOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.MonikerOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p MonikerProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *MonikerProvider) UnmarshalJSON(data []byte) error {
+	*p = MonikerProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// InlayHintProvider is the InlayHintProvider of ServerCapabilities, which is either bool, InlayHintOptions or InlayHintRegistrationOptions.
+//
+// At most one of Options or RegistrationOptions is set. If neither is set, Value is used.
+//
+// @since 3.17.0.
+type InlayHintProvider struct {
+	Value               bool
+	Options             *InlayHintOptions
+	RegistrationOptions *InlayHintRegistrationOptions
+}
+
+// compile time check whether the InlayHintProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*InlayHintProvider)(nil)
+	_ json.Unmarshaler = (*InlayHintProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *InlayHintProvider) forms() providerForms {
+	return providerForms{
+		value:               &p.Value,
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        textDocumentRegistration,
+	}
+}
+
+// Enabled reports whether the server provides inlay hint support.
+func (p *InlayHintProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// InlayHintOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *InlayHintProvider) InlayHintOptions() *InlayHintOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.InlayHintOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p InlayHintProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *InlayHintProvider) UnmarshalJSON(data []byte) error {
+	*p = InlayHintProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// DiagnosticProvider is the DiagnosticProvider of ServerCapabilities, which is either DiagnosticOptions or DiagnosticRegistrationOptions.
+//
+// Exactly one of Options or RegistrationOptions is set.
+//
+// @since 3.17.0.
+type DiagnosticProvider struct {
+	Options             *DiagnosticOptions
+	RegistrationOptions *DiagnosticRegistrationOptions
+}
+
+// compile time check whether the DiagnosticProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*DiagnosticProvider)(nil)
+	_ json.Unmarshaler = (*DiagnosticProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *DiagnosticProvider) forms() providerForms {
+	return providerForms{
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        textDocumentRegistration,
+	}
+}
+
+// Enabled reports whether the server provides pull diagnostics support.
+func (p *DiagnosticProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// DiagnosticOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *DiagnosticProvider) DiagnosticOptions() *DiagnosticOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.DiagnosticOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p DiagnosticProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *DiagnosticProvider) UnmarshalJSON(data []byte) error {
+	*p = DiagnosticProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// TypeHierarchyProvider is the TypeHierarchyProvider of ServerCapabilities, which is either bool, TypeHierarchyOptions or TypeHierarchyRegistrationOptions.
+//
+// At most one of Options or RegistrationOptions is set. If neither is set, Value is used.
+//
+// @since 3.17.0.
+type TypeHierarchyProvider struct {
+	Value               bool
+	Options             *TypeHierarchyOptions
+	RegistrationOptions *TypeHierarchyRegistrationOptions
+}
+
+// compile time check whether the TypeHierarchyProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*TypeHierarchyProvider)(nil)
+	_ json.Unmarshaler = (*TypeHierarchyProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *TypeHierarchyProvider) forms() providerForms {
+	return providerForms{
+		value:               &p.Value,
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        textDocumentRegistration,
+	}
+}
+
+// Enabled reports whether the server provides type hierarchy support.
+func (p *TypeHierarchyProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// TypeHierarchyOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *TypeHierarchyProvider) TypeHierarchyOptions() *TypeHierarchyOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.TypeHierarchyOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p TypeHierarchyProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *TypeHierarchyProvider) UnmarshalJSON(data []byte) error {
+	*p = TypeHierarchyProvider{}
+
+	return p.forms().unmarshal(data)
+}
+
+// NotebookDocumentSync is the NotebookDocumentSync of ServerCapabilities, which is either NotebookDocumentSyncOptions or NotebookDocumentSyncRegistrationOptions.
+//
+// Exactly one of Options or RegistrationOptions is set. As the two forms only differ by the optional id of the
+// registration options, decoding always sets RegistrationOptions: a NotebookDocumentSync built with Options does
+// not get them back in Options after a round trip, but in the NotebookDocumentSyncOptions of RegistrationOptions,
+// which NotebookDocumentSyncOptions returns either way.
+//
+// @since 3.17.0.
+type NotebookDocumentSync struct {
+	Options             *NotebookDocumentSyncOptions
+	RegistrationOptions *NotebookDocumentSyncRegistrationOptions
+}
+
+// compile time check whether the NotebookDocumentSync implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*NotebookDocumentSync)(nil)
+	_ json.Unmarshaler = (*NotebookDocumentSync)(nil)
+)
+
+// forms returns the forms of p.
+func (p *NotebookDocumentSync) forms() providerForms {
+	return providerForms{
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        staticRegistration,
+	}
+}
+
+// Enabled reports whether the server provides notebook document sync support.
+func (p *NotebookDocumentSync) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// NotebookDocumentSyncOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *NotebookDocumentSync) NotebookDocumentSyncOptions() *NotebookDocumentSyncOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.NotebookDocumentSyncOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p NotebookDocumentSync) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *NotebookDocumentSync) UnmarshalJSON(data []byte) error {
+	*p = NotebookDocumentSync{}
+
+	return p.forms().unmarshal(data)
+}
+
+// InlineValueProvider is the InlineValueProvider of ServerCapabilities, which is either bool, InlineValueOptions or InlineValueRegistrationOptions.
+//
+// At most one of Options or RegistrationOptions is set. If neither is set, Value is used.
+//
+// @since 3.17.0.
+type InlineValueProvider struct {
+	Value               bool
+	Options             *InlineValueOptions
+	RegistrationOptions *InlineValueRegistrationOptions
+}
+
+// compile time check whether the InlineValueProvider implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*InlineValueProvider)(nil)
+	_ json.Unmarshaler = (*InlineValueProvider)(nil)
+)
+
+// forms returns the forms of p.
+func (p *InlineValueProvider) forms() providerForms {
+	return providerForms{
+		value:               &p.Value,
+		options:             &p.Options,
+		registrationOptions: &p.RegistrationOptions,
+		registration:        textDocumentRegistration,
+	}
+}
+
+// Enabled reports whether the server provides inline value support.
+func (p *InlineValueProvider) Enabled() bool {
+	return p != nil && p.forms().enabled()
+}
+
+// InlineValueOptions returns the options of the provider, taken from the RegistrationOptions if needed.
+func (p *InlineValueProvider) InlineValueOptions() *InlineValueOptions {
+	switch {
+	case p == nil:
+		return nil
+	case p.Options != nil:
+		return p.Options
+	case p.RegistrationOptions != nil:
+		return &p.RegistrationOptions.InlineValueOptions
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p InlineValueProvider) MarshalJSON() ([]byte, error) {
+	return p.forms().marshal()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *InlineValueProvider) UnmarshalJSON(data []byte) error {
+	*p = InlineValueProvider{}
+
+	return p.forms().unmarshal(data)
+}
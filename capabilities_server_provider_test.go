@@ -0,0 +1,859 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/segmentio/encoding/json"
+)
+
+func TestTextDocumentSync(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantKind    = `2`
+		wantOptions = `{"openClose":true,"change":1,"save":{"includeText":true}}`
+		wantInvalid = `1`
+	)
+	wantTypeKind := TextDocumentSync{
+		Kind: TextDocumentSyncKindIncremental,
+	}
+	wantTypeOptions := TextDocumentSync{
+		Options: &TextDocumentSyncOptions{
+			OpenClose: true,
+			Change:    TextDocumentSyncKindFull,
+			Save: &SaveOptions{
+				IncludeText: true,
+			},
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          TextDocumentSync
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Kind",
+				field:          wantTypeKind,
+				want:           wantKind,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Options",
+				field:          wantTypeOptions,
+				want:           wantOptions,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantTypeKind,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             TextDocumentSync
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Kind",
+				field:            wantKind,
+				want:             wantTypeKind,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Options",
+				field:            wantOptions,
+				want:             wantTypeOptions,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantTypeKind,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got TextDocumentSync
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Accessors", func(t *testing.T) {
+		t.Parallel()
+
+		var nilSync *TextDocumentSync
+		if nilSync.Enabled() {
+			t.Error("nil TextDocumentSync must not be enabled")
+		}
+		if got := (&TextDocumentSync{}).Enabled(); got {
+			t.Error("TextDocumentSyncKindNone must not be enabled")
+		}
+		if got := wantTypeKind.TextDocumentSyncKind(); got != TextDocumentSyncKindIncremental {
+			t.Errorf("TextDocumentSyncKind() = %v, want %v", got, TextDocumentSyncKindIncremental)
+		}
+		if got := wantTypeOptions.TextDocumentSyncKind(); got != TextDocumentSyncKindFull {
+			t.Errorf("TextDocumentSyncKind() = %v, want %v", got, TextDocumentSyncKindFull)
+		}
+
+		want := &TextDocumentSyncOptions{
+			OpenClose: true,
+			Change:    TextDocumentSyncKindIncremental,
+		}
+		if diff := cmp.Diff(want, wantTypeKind.TextDocumentSyncOptions()); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+	})
+}
+
+func TestHoverProvider(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantTrue    = `true`
+		wantFalse   = `false`
+		wantOptions = `{"workDoneProgress":true}`
+	)
+	wantTypeTrue := HoverProvider{
+		Value: true,
+	}
+	wantTypeOptions := HoverProvider{
+		Options: &HoverOptions{
+			WorkDoneProgressOptions: WorkDoneProgressOptions{
+				WorkDoneProgress: true,
+			},
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          HoverProvider
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "True",
+				field:          wantTypeTrue,
+				want:           wantTrue,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "False",
+				field:          HoverProvider{},
+				want:           wantFalse,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Options",
+				field:          wantTypeOptions,
+				want:           wantOptions,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantTypeOptions,
+				want:           wantTrue,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             HoverProvider
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "True",
+				field:            wantTrue,
+				want:             wantTypeTrue,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "False",
+				field:            wantFalse,
+				want:             HoverProvider{},
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Options",
+				field:            wantOptions,
+				want:             wantTypeOptions,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantTrue,
+				want:             wantTypeOptions,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got HoverProvider
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+}
+
+func TestDeclarationProvider(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantTrue                        = `true`
+		wantFalse                       = `false`
+		wantOptions                     = `{"workDoneProgress":true}`
+		wantRegistrationOptions         = `{"workDoneProgress":true,"documentSelector":[{"language":"go"}],"id":"testID"}`
+		wantRegistrationOptionsSelector = `{"documentSelector":null}`
+	)
+	wantTypeOptions := DeclarationProvider{
+		Options: &DeclarationOptions{
+			WorkDoneProgressOptions: WorkDoneProgressOptions{
+				WorkDoneProgress: true,
+			},
+		},
+	}
+	wantTypeRegistrationOptions := DeclarationProvider{
+		RegistrationOptions: &DeclarationRegistrationOptions{
+			DeclarationOptions: DeclarationOptions{
+				WorkDoneProgressOptions: WorkDoneProgressOptions{
+					WorkDoneProgress: true,
+				},
+			},
+			TextDocumentRegistrationOptions: TextDocumentRegistrationOptions{
+				DocumentSelector: DocumentSelector{
+					{
+						Language: "go",
+					},
+				},
+			},
+			StaticRegistrationOptions: StaticRegistrationOptions{
+				ID: "testID",
+			},
+		},
+	}
+	wantTypeRegistrationOptionsSelector := DeclarationProvider{
+		RegistrationOptions: &DeclarationRegistrationOptions{},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          DeclarationProvider
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "True",
+				field:          DeclarationProvider{Value: true},
+				want:           wantTrue,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "False",
+				field:          DeclarationProvider{},
+				want:           wantFalse,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Options",
+				field:          wantTypeOptions,
+				want:           wantOptions,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "RegistrationOptions",
+				field:          wantTypeRegistrationOptions,
+				want:           wantRegistrationOptions,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "RegistrationOptionsNilSelector",
+				field:          wantTypeRegistrationOptionsSelector,
+				want:           wantRegistrationOptionsSelector,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             DeclarationProvider
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "True",
+				field:            wantTrue,
+				want:             DeclarationProvider{Value: true},
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "False",
+				field:            wantFalse,
+				want:             DeclarationProvider{},
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Options",
+				field:            wantOptions,
+				want:             wantTypeOptions,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "RegistrationOptions",
+				field:            wantRegistrationOptions,
+				want:             wantTypeRegistrationOptions,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "RegistrationOptionsNilSelector",
+				field:            wantRegistrationOptionsSelector,
+				want:             wantTypeRegistrationOptionsSelector,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got DeclarationProvider
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Accessors", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name        string
+			provider    *DeclarationProvider
+			wantEnabled bool
+			wantOptions *DeclarationOptions
+		}{
+			{
+				name:        "Nil",
+				provider:    nil,
+				wantEnabled: false,
+				wantOptions: nil,
+			},
+			{
+				name:        "False",
+				provider:    &DeclarationProvider{},
+				wantEnabled: false,
+				wantOptions: nil,
+			},
+			{
+				name:        "True",
+				provider:    &DeclarationProvider{Value: true},
+				wantEnabled: true,
+				wantOptions: nil,
+			},
+			{
+				name:        "Options",
+				provider:    &wantTypeOptions,
+				wantEnabled: true,
+				wantOptions: wantTypeOptions.Options,
+			},
+			{
+				name:        "RegistrationOptions",
+				provider:    &wantTypeRegistrationOptions,
+				wantEnabled: true,
+				wantOptions: &wantTypeRegistrationOptions.RegistrationOptions.DeclarationOptions,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				if got := tt.provider.Enabled(); got != tt.wantEnabled {
+					t.Errorf("Enabled() = %t, want %t", got, tt.wantEnabled)
+				}
+				if diff := cmp.Diff(tt.wantOptions, tt.provider.DeclarationOptions()); diff != "" {
+					t.Errorf("DeclarationOptions(): (-want +got)\n%s", diff)
+				}
+			})
+		}
+	})
+}
+
+func TestDiagnosticProvider(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantOptions             = `{"identifier":"test","interFileDependencies":true,"workspaceDiagnostics":false}`
+		wantRegistrationOptions = `{"documentSelector":null,"identifier":"test","interFileDependencies":true,"workspaceDiagnostics":false}`
+	)
+	options := DiagnosticOptions{
+		Identifier:            "test",
+		InterFileDependencies: true,
+	}
+	wantTypeOptions := DiagnosticProvider{
+		Options: &options,
+	}
+	wantTypeRegistrationOptions := DiagnosticProvider{
+		RegistrationOptions: &DiagnosticRegistrationOptions{
+			DiagnosticOptions: options,
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          DiagnosticProvider
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Options",
+				field:          wantTypeOptions,
+				want:           wantOptions,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "RegistrationOptions",
+				field:          wantTypeRegistrationOptions,
+				want:           wantRegistrationOptions,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "InvalidEmpty",
+				field:          DiagnosticProvider{},
+				want:           "",
+				wantMarshalErr: true,
+				wantErr:        false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             DiagnosticProvider
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Options",
+				field:            wantOptions,
+				want:             wantTypeOptions,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "RegistrationOptions",
+				field:            wantRegistrationOptions,
+				want:             wantTypeRegistrationOptions,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "InvalidBool",
+				field:            `true`,
+				want:             DiagnosticProvider{},
+				wantUnmarshalErr: true,
+				wantErr:          false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got DiagnosticProvider
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+}
+
+func TestNotebookDocumentSync(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantOptions             = `{"notebookSelector":[{"notebook":"jupyter-notebook"}],"save":true}`
+		wantRegistrationOptions = `{"notebookSelector":[{"notebook":"jupyter-notebook"}],"save":true,"id":"notebook"}`
+	)
+	options := NotebookDocumentSyncOptions{
+		NotebookSelector: []NotebookDocumentSyncOptionsNotebookSelector{
+			{
+				Notebook: "jupyter-notebook",
+			},
+		},
+		Save: true,
+	}
+	wantTypeOptions := NotebookDocumentSync{
+		Options: &options,
+	}
+	wantTypeRegistrationOptions := NotebookDocumentSync{
+		RegistrationOptions: &NotebookDocumentSyncRegistrationOptions{
+			NotebookDocumentSyncOptions: options,
+			StaticRegistrationOptions: StaticRegistrationOptions{
+				ID: "notebook",
+			},
+		},
+	}
+	wantTypeRegistrationOptionsNoID := NotebookDocumentSync{
+		RegistrationOptions: &NotebookDocumentSyncRegistrationOptions{
+			NotebookDocumentSyncOptions: options,
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          NotebookDocumentSync
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Options",
+				field:          wantTypeOptions,
+				want:           wantOptions,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "RegistrationOptions",
+				field:          wantTypeRegistrationOptions,
+				want:           wantRegistrationOptions,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "RegistrationOptionsNoID",
+				field:          wantTypeRegistrationOptionsNoID,
+				want:           wantOptions,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "InvalidEmpty",
+				field:          NotebookDocumentSync{},
+				want:           "",
+				wantMarshalErr: true,
+				wantErr:        false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             NotebookDocumentSync
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "RegistrationOptions",
+				field:            wantRegistrationOptions,
+				want:             wantTypeRegistrationOptions,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "RegistrationOptionsNoID",
+				field:            wantOptions,
+				want:             wantTypeRegistrationOptionsNoID,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "InvalidBool",
+				field:            `true`,
+				want:             NotebookDocumentSync{},
+				wantUnmarshalErr: true,
+				wantErr:          false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got NotebookDocumentSync
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+
+				if tt.wantUnmarshalErr {
+					return
+				}
+				if diff := cmp.Diff(&options, got.NotebookDocumentSyncOptions()); diff != "" {
+					t.Errorf("NotebookDocumentSyncOptions(): (-want +got)\n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("RoundTripOptions", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(wantTypeOptions)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got NotebookDocumentSync
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+
+		// the options form always decodes into RegistrationOptions.
+		if diff := cmp.Diff(wantTypeRegistrationOptionsNoID, got); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+		if diff := cmp.Diff(wantTypeOptions.NotebookDocumentSyncOptions(), got.NotebookDocumentSyncOptions()); diff != "" {
+			t.Errorf("NotebookDocumentSyncOptions(): (-want +got)\n%s", diff)
+		}
+	})
+}
+
+func TestServerCapabilities_Providers(t *testing.T) {
+	t.Parallel()
+
+	const want = `{"hoverProvider":false,"definitionProvider":true,"codeActionProvider":{"codeActionKinds":["quickfix"]},"renameProvider":{"prepareProvider":true},"semanticTokensProvider":{"documentSelector":null,"legend":{"tokenTypes":["namespace","type"],"tokenModifiers":["declaration"]},"range":true,"full":{"delta":true},"id":"semanticTokens"},"notebookDocumentSync":{"notebookSelector":[],"id":"notebook"}}`
+
+	var got ServerCapabilities
+	if err := json.Unmarshal([]byte(want), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.HoverProvider == nil {
+		t.Error("explicit false HoverProvider must be decoded")
+	}
+	if got.HoverProvider.Enabled() {
+		t.Error("HoverProvider must not be enabled")
+	}
+	if !got.DefinitionProvider.Enabled() {
+		t.Error("DefinitionProvider must be enabled")
+	}
+	if got.DeclarationProvider.Enabled() {
+		t.Error("DeclarationProvider must not be enabled")
+	}
+	if opts := got.CodeActionProvider.CodeActionOptions(); opts == nil || len(opts.CodeActionKinds) != 1 {
+		t.Errorf("CodeActionOptions() = %#v", opts)
+	}
+	if opts := got.RenameProvider.RenameOptions(); opts == nil || !opts.PrepareProvider {
+		t.Errorf("RenameOptions() = %#v", opts)
+	}
+	if got.SemanticTokensProvider.RegistrationOptions == nil || got.SemanticTokensProvider.RegistrationOptions.ID != "semanticTokens" {
+		t.Errorf("SemanticTokensProvider = %#v", got.SemanticTokensProvider)
+	}
+	wantLegend := SemanticTokensLegend{
+		TokenTypes:     []SemanticTokenTypes{SemanticTokenNamespace, SemanticTokenType},
+		TokenModifiers: []SemanticTokenModifiers{SemanticTokenModifierDeclaration},
+	}
+	if opts := got.SemanticTokensProvider.SemanticTokensOptions(); opts == nil || !cmp.Equal(wantLegend, opts.Legend) {
+		t.Errorf("SemanticTokensOptions() = %#v", opts)
+	}
+	if got.NotebookDocumentSync.RegistrationOptions == nil || got.NotebookDocumentSync.RegistrationOptions.ID != "notebook" {
+		t.Errorf("NotebookDocumentSync = %#v", got.NotebookDocumentSync)
+	}
+
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, string(data)); diff != "" {
+		t.Errorf("(-want +got)\n%s", diff)
+	}
+}
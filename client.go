@@ -198,6 +198,73 @@ func clientDispatch(ctx context.Context, client Client, reply jsonrpc2.Replier,
 
 		return true, reply(ctx, resp, err)
 
+	case MethodWorkspaceInlayHintRefresh: // request
+		defer logger.Debug(MethodWorkspaceInlayHintRefresh, zap.Error(err))
+
+		if len(req.Params()) > 0 {
+			return true, reply(ctx, nil, fmt.Errorf("expected no params: %w", jsonrpc2.ErrInvalidParams))
+		}
+
+		err := client.InlayHintRefresh(ctx)
+
+		return true, reply(ctx, nil, err)
+
+	case MethodWorkspaceDiagnosticRefresh: // request
+		defer logger.Debug(MethodWorkspaceDiagnosticRefresh, zap.Error(err))
+
+		if len(req.Params()) > 0 {
+			return true, reply(ctx, nil, fmt.Errorf("expected no params: %w", jsonrpc2.ErrInvalidParams))
+		}
+
+		err := client.DiagnosticRefresh(ctx)
+
+		return true, reply(ctx, nil, err)
+
+	case MethodWorkspaceInlineValueRefresh: // request
+		defer logger.Debug(MethodWorkspaceInlineValueRefresh, zap.Error(err))
+
+		if len(req.Params()) > 0 {
+			return true, reply(ctx, nil, fmt.Errorf("expected no params: %w", jsonrpc2.ErrInvalidParams))
+		}
+
+		err := client.InlineValueRefresh(ctx)
+
+		return true, reply(ctx, nil, err)
+
+	case MethodShowDocument: // request
+		defer logger.Debug(MethodShowDocument, zap.Error(err))
+
+		var params ShowDocumentParams
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		resp, err := client.ShowDocument(ctx, &params)
+
+		return true, reply(ctx, resp, err)
+
+	case MethodCodeLensRefresh: // request
+		defer logger.Debug(MethodCodeLensRefresh, zap.Error(err))
+
+		if len(req.Params()) > 0 {
+			return true, reply(ctx, nil, fmt.Errorf("expected no params: %w", jsonrpc2.ErrInvalidParams))
+		}
+
+		err := client.CodeLensRefresh(ctx)
+
+		return true, reply(ctx, nil, err)
+
+	case MethodSemanticTokensRefresh: // request
+		defer logger.Debug(MethodSemanticTokensRefresh, zap.Error(err))
+
+		if len(req.Params()) > 0 {
+			return true, reply(ctx, nil, fmt.Errorf("expected no params: %w", jsonrpc2.ErrInvalidParams))
+		}
+
+		err := client.SemanticTokensRefresh(ctx)
+
+		return true, reply(ctx, nil, err)
+
 	default:
 		return false, nil
 	}
@@ -217,6 +284,12 @@ type Client interface {
 	ApplyEdit(ctx context.Context, params *ApplyWorkspaceEditParams) (result bool, err error)
 	Configuration(ctx context.Context, params *ConfigurationParams) (result []interface{}, err error)
 	WorkspaceFolders(ctx context.Context) (result []WorkspaceFolder, err error)
+	InlayHintRefresh(ctx context.Context) (err error)
+	DiagnosticRefresh(ctx context.Context) (err error)
+	InlineValueRefresh(ctx context.Context) (err error)
+	ShowDocument(ctx context.Context, params *ShowDocumentParams) (result *ShowDocumentResult, err error)
+	CodeLensRefresh(ctx context.Context) (err error)
+	SemanticTokensRefresh(ctx context.Context) (err error)
 }
 
 // list of client methods.
@@ -256,6 +329,24 @@ const (
 
 	// MethodWorkspaceWorkspaceFolders method name of "workspace/workspaceFolders".
 	MethodWorkspaceWorkspaceFolders = "workspace/workspaceFolders"
+
+	// MethodWorkspaceInlayHintRefresh method name of "workspace/inlayHint/refresh".
+	MethodWorkspaceInlayHintRefresh = "workspace/inlayHint/refresh"
+
+	// MethodWorkspaceDiagnosticRefresh method name of "workspace/diagnostic/refresh".
+	MethodWorkspaceDiagnosticRefresh = "workspace/diagnostic/refresh"
+
+	// MethodWorkspaceInlineValueRefresh method name of "workspace/inlineValue/refresh".
+	MethodWorkspaceInlineValueRefresh = "workspace/inlineValue/refresh"
+
+	// MethodShowDocument method name of "window/showDocument".
+	MethodShowDocument = "window/showDocument"
+
+	// MethodCodeLensRefresh method name of "workspace/codeLens/refresh".
+	MethodCodeLensRefresh = "workspace/codeLens/refresh"
+
+	// MethodSemanticTokensRefresh method name of "workspace/semanticTokens/refresh".
+	MethodSemanticTokensRefresh = "workspace/semanticTokens/refresh"
 )
 
 // client implements a Language Server Protocol client.
@@ -410,3 +501,88 @@ func (c *client) WorkspaceFolders(ctx context.Context) (result []WorkspaceFolder
 
 	return result, nil
 }
+
+// InlayHintRefresh sends the request from the server to the client to ask the client to refresh the inlay hints currently shown in editors.
+//
+// As a result the client should ask the server to recompute the inlay hints for these editors.
+// This is useful if a server detects a configuration change which requires a re-calculation of all inlay hints.
+// Note that the client still has the freedom to delay the re-calculation of the inlay hints if for example an editor is currently not visible.
+//
+// @since 3.17.0.
+func (c *client) InlayHintRefresh(ctx context.Context) (err error) {
+	c.logger.Debug("call " + MethodWorkspaceInlayHintRefresh)
+	defer c.logger.Debug("end "+MethodWorkspaceInlayHintRefresh, zap.Error(err))
+
+	return Call(ctx, c.Conn, MethodWorkspaceInlayHintRefresh, nil, nil)
+}
+
+// DiagnosticRefresh sends the request from the server to the client to ask the client to refresh all needed document and workspace diagnostics.
+//
+// This is useful if a server detects a project wide configuration change which requires a re-calculation of all diagnostics.
+//
+// @since 3.17.0.
+func (c *client) DiagnosticRefresh(ctx context.Context) (err error) {
+	c.logger.Debug("call " + MethodWorkspaceDiagnosticRefresh)
+	defer c.logger.Debug("end "+MethodWorkspaceDiagnosticRefresh, zap.Error(err))
+
+	return Call(ctx, c.Conn, MethodWorkspaceDiagnosticRefresh, nil, nil)
+}
+
+// InlineValueRefresh sends the request from the server to the client to ask the client to refresh the inline values currently shown in editors.
+//
+// As a result the client should ask the server to recompute the inline values for these editors.
+// This is useful if a server detects a configuration change which requires a re-calculation of all inline values.
+// Note that the client still has the freedom to delay the re-calculation of the inline values if for example an editor is currently not visible.
+//
+// @since 3.17.0.
+func (c *client) InlineValueRefresh(ctx context.Context) (err error) {
+	c.logger.Debug("call " + MethodWorkspaceInlineValueRefresh)
+	defer c.logger.Debug("end "+MethodWorkspaceInlineValueRefresh, zap.Error(err))
+
+	return Call(ctx, c.Conn, MethodWorkspaceInlineValueRefresh, nil, nil)
+}
+
+// ShowDocument sends the request from a server to a client to ask the client to display a particular document in the user interface.
+//
+// @since 3.16.0.
+func (c *client) ShowDocument(ctx context.Context, params *ShowDocumentParams) (result *ShowDocumentResult, err error) {
+	c.logger.Debug("call " + MethodShowDocument)
+	defer c.logger.Debug("end "+MethodShowDocument, zap.Error(err))
+
+	if err := Call(ctx, c.Conn, MethodShowDocument, params, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CodeLensRefresh sent from the server to the client.
+//
+// Servers can use it to ask clients to refresh the code lenses currently shown in editors.
+// As a result the client should ask the server to recompute the code lenses for these editors.
+// This is useful if a server detects a configuration change which requires a re-calculation of all code lenses.
+//
+// Note that the client still has the freedom to delay the re-calculation of the code lenses if for example an editor is currently not visible.
+//
+// @since 3.16.0.
+func (c *client) CodeLensRefresh(ctx context.Context) (err error) {
+	c.logger.Debug("call " + MethodCodeLensRefresh)
+	defer c.logger.Debug("end "+MethodCodeLensRefresh, zap.Error(err))
+
+	return Call(ctx, c.Conn, MethodCodeLensRefresh, nil, nil)
+}
+
+// SemanticTokensRefresh is sent from the server to the client. Servers can use it to ask clients to refresh the editors for which this server provides semantic tokens.
+//
+// As a result the client should ask the server to recompute the semantic tokens for these editors.
+// This is useful if a server detects a project wide configuration change which requires a re-calculation of all semantic tokens.
+//
+// Note that the client still has the freedom to delay the re-calculation of the semantic tokens if for example an editor is currently not visible.
+//
+// @since 3.16.0.
+func (c *client) SemanticTokensRefresh(ctx context.Context) (err error) {
+	c.logger.Debug("call " + MethodSemanticTokensRefresh)
+	defer c.logger.Debug("end "+MethodSemanticTokensRefresh, zap.Error(err))
+
+	return Call(ctx, c.Conn, MethodSemanticTokensRefresh, nil, nil)
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"go.lsp.dev/uri"
+)
+
+// recordClient is a Client which records the server to client requests it receives.
+type recordClient struct {
+	Client
+
+	mu      sync.Mutex
+	methods []string
+	params  *ShowDocumentParams
+}
+
+func (c *recordClient) record(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.methods = append(c.methods, method)
+}
+
+// ShowDocument implements Client.
+func (c *recordClient) ShowDocument(_ context.Context, params *ShowDocumentParams) (*ShowDocumentResult, error) {
+	c.record(MethodShowDocument)
+
+	c.mu.Lock()
+	c.params = params
+	c.mu.Unlock()
+
+	return &ShowDocumentResult{Success: true}, nil
+}
+
+// CodeLensRefresh implements Client.
+func (c *recordClient) CodeLensRefresh(context.Context) error {
+	c.record(MethodCodeLensRefresh)
+
+	return nil
+}
+
+// SemanticTokensRefresh implements Client.
+func (c *recordClient) SemanticTokensRefresh(context.Context) error {
+	c.record(MethodSemanticTokensRefresh)
+
+	return nil
+}
+
+func TestClientDispatcher_ServerToClientRequests(t *testing.T) {
+	t.Parallel()
+
+	client := &recordClient{}
+	_, dispatcher := newTestConnPair(t, nil, client)
+	ctx := context.Background()
+
+	params := &ShowDocumentParams{
+		URI:       uri.File("/path/to/basic.go"),
+		TakeFocus: true,
+		Selection: &Range{
+			Start: Position{
+				Line:      25,
+				Character: 1,
+			},
+			End: Position{
+				Line:      27,
+				Character: 3,
+			},
+		},
+	}
+	result, err := dispatcher.ShowDocument(ctx, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Success {
+		t.Errorf("ShowDocument: want success")
+	}
+
+	if err := dispatcher.CodeLensRefresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dispatcher.SemanticTokensRefresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	if diff := cmp.Diff(params, client.params); diff != "" {
+		t.Errorf("params: (-want +got)\n%s", diff)
+	}
+
+	want := []string{MethodShowDocument, MethodCodeLensRefresh, MethodSemanticTokensRefresh}
+	if diff := cmp.Diff(want, client.methods); diff != "" {
+		t.Errorf("methods: (-want +got)\n%s", diff)
+	}
+}
@@ -4,7 +4,12 @@
 package protocol
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"strconv"
+
+	"github.com/segmentio/encoding/json"
 )
 
 // Diagnostic represents a diagnostic, such as a compiler error or warning.
@@ -19,7 +24,7 @@ type Diagnostic struct {
 	Severity DiagnosticSeverity `json:"severity,omitempty"`
 
 	// Code is the diagnostic's code, which might appear in the user interface.
-	Code interface{} `json:"code,omitempty"` // int32 | string;
+	Code *DiagnosticCode `json:"code,omitempty"` // int32 | string;
 
 	// CodeDescription an optional property to describe the error code.
 	//
@@ -50,6 +55,92 @@ type Diagnostic struct {
 	Data interface{} `json:"data,omitempty"`
 }
 
+// DiagnosticCode is the code of a Diagnostic, which is either an integer or a string.
+//
+// Exactly one of IntValue, NumberValue or StringValue is set. NumberValue holds a number that is not an integer in the
+// range of int32, as sent by some servers, so that it is encoded back as the same number.
+type DiagnosticCode struct {
+	IntValue    *int32
+	NumberValue *json.Number
+	StringValue *string
+}
+
+// compile time check whether the DiagnosticCode implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*DiagnosticCode)(nil)
+	_ json.Unmarshaler = (*DiagnosticCode)(nil)
+)
+
+var errEmptyDiagnosticCode = errors.New("diagnostic code is neither an integer nor a string")
+
+// NewIntDiagnosticCode returns the integer DiagnosticCode of code.
+func NewIntDiagnosticCode(code int32) *DiagnosticCode {
+	return &DiagnosticCode{IntValue: &code}
+}
+
+// NewStringDiagnosticCode returns the string DiagnosticCode of code.
+func NewStringDiagnosticCode(code string) *DiagnosticCode {
+	return &DiagnosticCode{StringValue: &code}
+}
+
+// String implements fmt.Stringer.
+func (c DiagnosticCode) String() string {
+	switch {
+	case c.IntValue != nil:
+		return strconv.FormatInt(int64(*c.IntValue), 10)
+	case c.NumberValue != nil:
+		return c.NumberValue.String()
+	case c.StringValue != nil:
+		return *c.StringValue
+	default:
+		return ""
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c DiagnosticCode) MarshalJSON() ([]byte, error) {
+	switch {
+	case c.IntValue != nil:
+		return json.Marshal(c.IntValue)
+	case c.NumberValue != nil:
+		return json.Marshal(c.NumberValue)
+	case c.StringValue != nil:
+		return json.Marshal(c.StringValue)
+	default:
+		return nil, errEmptyDiagnosticCode
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// A number that is not an integer in the range of int32 is kept as the NumberValue rather than failing the whole
+// Diagnostic.
+func (c *DiagnosticCode) UnmarshalJSON(data []byte) error {
+	*c = DiagnosticCode{}
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return errEmptyDiagnosticCode
+	}
+
+	if data[0] == '"' {
+		return json.Unmarshal(data, &c.StringValue)
+	}
+
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+	if i, err := strconv.ParseInt(number.String(), 10, 32); err == nil {
+		code := int32(i)
+		c.IntValue = &code
+		return nil
+	}
+	c.NumberValue = &number
+
+	return nil
+}
+
 // DiagnosticSeverity indicates the severity of a Diagnostic message.
 type DiagnosticSeverity float64
 
@@ -147,3 +238,392 @@ type PublishDiagnosticsParams struct {
 	// Diagnostics an array of diagnostic information items.
 	Diagnostics []Diagnostic `json:"diagnostics"`
 }
+
+// DocumentDiagnosticParams params of the document diagnostic request.
+//
+// @since 3.17.0.
+type DocumentDiagnosticParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// TextDocument is the text document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Identifier is the additional identifier provided during registration.
+	Identifier string `json:"identifier,omitempty"`
+
+	// PreviousResultID is the result id of a previous response if provided.
+	PreviousResultID string `json:"previousResultId,omitempty"`
+}
+
+// DocumentDiagnosticReportKind is the document diagnostic report kinds.
+//
+// @since 3.17.0.
+type DocumentDiagnosticReportKind string
+
+// list of DocumentDiagnosticReportKind.
+const (
+	// DocumentDiagnosticReportKindFull is a diagnostic report with a full
+	// set of problems.
+	DocumentDiagnosticReportKindFull DocumentDiagnosticReportKind = "full"
+
+	// DocumentDiagnosticReportKindUnchanged is a report indicating that the
+	// last returned report is still accurate.
+	DocumentDiagnosticReportKindUnchanged DocumentDiagnosticReportKind = "unchanged"
+)
+
+// FullDocumentDiagnosticReport is a diagnostic report with a full set of problems.
+//
+// @since 3.17.0.
+type FullDocumentDiagnosticReport struct {
+	// Kind is a full document diagnostic report.
+	Kind DocumentDiagnosticReportKind `json:"kind"`
+
+	// ResultID an optional result id. If provided it will
+	// be sent on the next diagnostic request for the
+	// same document.
+	ResultID string `json:"resultId,omitempty"`
+
+	// Items is the actual items.
+	Items []Diagnostic `json:"items"`
+}
+
+// UnchangedDocumentDiagnosticReport is a diagnostic report indicating that the last returned
+// report is still accurate.
+//
+// @since 3.17.0.
+type UnchangedDocumentDiagnosticReport struct {
+	// Kind is a document diagnostic report indicating
+	// no changes to the last result. A server can
+	// only return "unchanged" if result ids are
+	// provided.
+	Kind DocumentDiagnosticReportKind `json:"kind"`
+
+	// ResultID is a result id which will be sent on the next
+	// diagnostic request for the same document.
+	ResultID string `json:"resultId"`
+}
+
+// RelatedFullDocumentDiagnosticReport is a full diagnostic report with a set of related documents.
+//
+// @since 3.17.0.
+type RelatedFullDocumentDiagnosticReport struct {
+	FullDocumentDiagnosticReport
+
+	// RelatedDocuments diagnostics of related documents. This information is useful
+	// in programming languages where code in a file A can generate
+	// diagnostics in a file B which A depends on. An example of
+	// such a language is C/C++ where marco definitions in a file
+	// a.cpp and result in errors in a header file b.hpp.
+	RelatedDocuments map[DocumentURI]DocumentDiagnosticReportItem `json:"relatedDocuments,omitempty"`
+}
+
+// RelatedUnchangedDocumentDiagnosticReport is an unchanged diagnostic report with a set of related documents.
+//
+// @since 3.17.0.
+type RelatedUnchangedDocumentDiagnosticReport struct {
+	UnchangedDocumentDiagnosticReport
+
+	// RelatedDocuments diagnostics of related documents. This information is useful
+	// in programming languages where code in a file A can generate
+	// diagnostics in a file B which A depends on. An example of
+	// such a language is C/C++ where marco definitions in a file
+	// a.cpp and result in errors in a header file b.hpp.
+	RelatedDocuments map[DocumentURI]DocumentDiagnosticReportItem `json:"relatedDocuments,omitempty"`
+}
+
+// DocumentDiagnosticReportItem is either a full or an unchanged document diagnostic report.
+//
+// Exactly one of Full or Unchanged is set. The JSON representation is
+// dispatched on the "kind" property.
+//
+// @since 3.17.0.
+type DocumentDiagnosticReportItem struct {
+	Full      *FullDocumentDiagnosticReport
+	Unchanged *UnchangedDocumentDiagnosticReport
+}
+
+// compile time check whether the DocumentDiagnosticReportItem implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*DocumentDiagnosticReportItem)(nil)
+	_ json.Unmarshaler = (*DocumentDiagnosticReportItem)(nil)
+)
+
+// MarshalJSON implements json.Marshaler.
+func (v DocumentDiagnosticReportItem) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.Full != nil:
+		report := v.Full.normalize()
+		return json.Marshal(&report)
+	case v.Unchanged != nil:
+		report := v.Unchanged.normalize()
+		return json.Marshal(&report)
+	default:
+		return nil, errEmptyDocumentDiagnosticReport
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *DocumentDiagnosticReportItem) UnmarshalJSON(data []byte) error {
+	*v = DocumentDiagnosticReportItem{}
+
+	kind, err := documentDiagnosticReportKind(data)
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case DocumentDiagnosticReportKindFull:
+		v.Full = &FullDocumentDiagnosticReport{}
+		return json.Unmarshal(data, v.Full)
+	default:
+		v.Unchanged = &UnchangedDocumentDiagnosticReport{}
+		return json.Unmarshal(data, v.Unchanged)
+	}
+}
+
+// DocumentDiagnosticReport is the result of a document diagnostic pull request.
+//
+// A report can either be a full report containing all diagnostics for the
+// requested document or an unchanged report indicating that nothing
+// has changed in terms of diagnostics in comparison to the last
+// pull request. Exactly one of Full or Unchanged is set.
+//
+// @since 3.17.0.
+type DocumentDiagnosticReport struct {
+	Full      *RelatedFullDocumentDiagnosticReport
+	Unchanged *RelatedUnchangedDocumentDiagnosticReport
+}
+
+// compile time check whether the DocumentDiagnosticReport implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*DocumentDiagnosticReport)(nil)
+	_ json.Unmarshaler = (*DocumentDiagnosticReport)(nil)
+)
+
+// MarshalJSON implements json.Marshaler.
+func (v DocumentDiagnosticReport) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.Full != nil:
+		report := *v.Full
+		report.FullDocumentDiagnosticReport = report.FullDocumentDiagnosticReport.normalize()
+		return json.Marshal(&report)
+	case v.Unchanged != nil:
+		report := *v.Unchanged
+		report.UnchangedDocumentDiagnosticReport = report.UnchangedDocumentDiagnosticReport.normalize()
+		return json.Marshal(&report)
+	default:
+		return nil, errEmptyDocumentDiagnosticReport
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *DocumentDiagnosticReport) UnmarshalJSON(data []byte) error {
+	*v = DocumentDiagnosticReport{}
+
+	kind, err := documentDiagnosticReportKind(data)
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case DocumentDiagnosticReportKindFull:
+		v.Full = &RelatedFullDocumentDiagnosticReport{}
+		return json.Unmarshal(data, v.Full)
+	default:
+		v.Unchanged = &RelatedUnchangedDocumentDiagnosticReport{}
+		return json.Unmarshal(data, v.Unchanged)
+	}
+}
+
+// DocumentDiagnosticReportPartialResult is a partial result for a document diagnostic report.
+//
+// @since 3.17.0.
+type DocumentDiagnosticReportPartialResult struct {
+	// RelatedDocuments diagnostics of related documents.
+	RelatedDocuments map[DocumentURI]DocumentDiagnosticReportItem `json:"relatedDocuments"`
+}
+
+// DiagnosticServerCancellationData cancellation data returned from a diagnostic request.
+//
+// @since 3.17.0.
+type DiagnosticServerCancellationData struct {
+	// RetriggerRequest whether the client should re-trigger the request.
+	RetriggerRequest bool `json:"retriggerRequest"`
+}
+
+// WorkspaceDiagnosticParams params of the workspace diagnostic request.
+//
+// @since 3.17.0.
+type WorkspaceDiagnosticParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// Identifier is the additional identifier provided during registration.
+	Identifier string `json:"identifier,omitempty"`
+
+	// PreviousResultIDs is the currently known diagnostic reports with their
+	// previous result ids.
+	//
+	// A nil PreviousResultIDs is sent as an empty array, as the specification requires one.
+	PreviousResultIDs []PreviousResultID `json:"previousResultIds"`
+}
+
+// compile time check whether the WorkspaceDiagnosticParams implements a json.Marshaler interface.
+var _ json.Marshaler = (*WorkspaceDiagnosticParams)(nil)
+
+// MarshalJSON implements json.Marshaler.
+func (p WorkspaceDiagnosticParams) MarshalJSON() ([]byte, error) {
+	// params has the fields of WorkspaceDiagnosticParams without its methods, so that Marshal does not recurse.
+	type params WorkspaceDiagnosticParams
+	if p.PreviousResultIDs == nil {
+		p.PreviousResultIDs = []PreviousResultID{}
+	}
+
+	return json.Marshal(params(p))
+}
+
+// PreviousResultID is a previous result id in a workspace pull request.
+//
+// @since 3.17.0.
+type PreviousResultID struct {
+	// URI is the URI for which the client knows a
+	// result id.
+	URI DocumentURI `json:"uri"`
+
+	// Value is the value of the previous result id.
+	Value string `json:"value"`
+}
+
+// WorkspaceDiagnosticReport is a workspace diagnostic report.
+//
+// @since 3.17.0.
+type WorkspaceDiagnosticReport struct {
+	// Items is the workspace diagnostic reports.
+	Items []WorkspaceDocumentDiagnosticReport `json:"items"`
+}
+
+// WorkspaceDiagnosticReportPartialResult is a partial result for a workspace diagnostic report.
+//
+// @since 3.17.0.
+type WorkspaceDiagnosticReportPartialResult struct {
+	// Items is the workspace diagnostic reports.
+	Items []WorkspaceDocumentDiagnosticReport `json:"items"`
+}
+
+// WorkspaceFullDocumentDiagnosticReport is a full document diagnostic report for a workspace diagnostic result.
+//
+// @since 3.17.0.
+type WorkspaceFullDocumentDiagnosticReport struct {
+	FullDocumentDiagnosticReport
+
+	// URI is the URI for which diagnostic information is reported.
+	URI DocumentURI `json:"uri"`
+
+	// Version is the version number for which the diagnostics are reported.
+	// If the document is not marked as open nil can be provided.
+	Version *int32 `json:"version"` // int32 | null
+}
+
+// WorkspaceUnchangedDocumentDiagnosticReport is an unchanged document diagnostic report for a workspace diagnostic result.
+//
+// @since 3.17.0.
+type WorkspaceUnchangedDocumentDiagnosticReport struct {
+	UnchangedDocumentDiagnosticReport
+
+	// URI is the URI for which diagnostic information is reported.
+	URI DocumentURI `json:"uri"`
+
+	// Version is the version number for which the diagnostics are reported.
+	// If the document is not marked as open nil can be provided.
+	Version *int32 `json:"version"` // int32 | null
+}
+
+// WorkspaceDocumentDiagnosticReport is a workspace diagnostic document report.
+//
+// Exactly one of Full or Unchanged is set. The JSON representation is
+// dispatched on the "kind" property.
+//
+// @since 3.17.0.
+type WorkspaceDocumentDiagnosticReport struct {
+	Full      *WorkspaceFullDocumentDiagnosticReport
+	Unchanged *WorkspaceUnchangedDocumentDiagnosticReport
+}
+
+// compile time check whether the WorkspaceDocumentDiagnosticReport implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*WorkspaceDocumentDiagnosticReport)(nil)
+	_ json.Unmarshaler = (*WorkspaceDocumentDiagnosticReport)(nil)
+)
+
+// MarshalJSON implements json.Marshaler.
+func (v WorkspaceDocumentDiagnosticReport) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.Full != nil:
+		report := *v.Full
+		report.FullDocumentDiagnosticReport = report.FullDocumentDiagnosticReport.normalize()
+		return json.Marshal(&report)
+	case v.Unchanged != nil:
+		report := *v.Unchanged
+		report.UnchangedDocumentDiagnosticReport = report.UnchangedDocumentDiagnosticReport.normalize()
+		return json.Marshal(&report)
+	default:
+		return nil, errEmptyDocumentDiagnosticReport
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *WorkspaceDocumentDiagnosticReport) UnmarshalJSON(data []byte) error {
+	*v = WorkspaceDocumentDiagnosticReport{}
+
+	kind, err := documentDiagnosticReportKind(data)
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case DocumentDiagnosticReportKindFull:
+		v.Full = &WorkspaceFullDocumentDiagnosticReport{}
+		return json.Unmarshal(data, v.Full)
+	default:
+		v.Unchanged = &WorkspaceUnchangedDocumentDiagnosticReport{}
+		return json.Unmarshal(data, v.Unchanged)
+	}
+}
+
+// errEmptyDocumentDiagnosticReport is returned when marshaling a diagnostic report union which has no variant set.
+var errEmptyDocumentDiagnosticReport = errors.New("document diagnostic report has neither full nor unchanged report")
+
+// normalize returns a copy of r with the "full" kind and non-null items.
+func (r FullDocumentDiagnosticReport) normalize() FullDocumentDiagnosticReport {
+	r.Kind = DocumentDiagnosticReportKindFull
+	if r.Items == nil {
+		r.Items = []Diagnostic{}
+	}
+
+	return r
+}
+
+// normalize returns a copy of r with the "unchanged" kind.
+func (r UnchangedDocumentDiagnosticReport) normalize() UnchangedDocumentDiagnosticReport {
+	r.Kind = DocumentDiagnosticReportKindUnchanged
+
+	return r
+}
+
+// documentDiagnosticReportKind reads the "kind" discriminator of the diagnostic report in data.
+func documentDiagnosticReportKind(data []byte) (DocumentDiagnosticReportKind, error) {
+	var report struct {
+		Kind DocumentDiagnosticReportKind `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return "", err
+	}
+
+	switch report.Kind {
+	case DocumentDiagnosticReportKindFull, DocumentDiagnosticReportKindUnchanged:
+		return report.Kind, nil
+	default:
+		return "", fmt.Errorf("unknown document diagnostic report kind: %q", report.Kind)
+	}
+}
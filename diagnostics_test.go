@@ -4,9 +4,11 @@
 package protocol
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/segmentio/encoding/json"
 
 	"go.lsp.dev/uri"
@@ -35,7 +37,7 @@ func TestDiagnostic(t *testing.T) {
 			},
 		},
 		Severity: DiagnosticSeverityError,
-		Code:     "foo/bar",
+		Code:     NewStringDiagnosticCode("foo/bar"),
 		CodeDescription: &CodeDescription{
 			Href: uri.File("/path/to/test.go"),
 		},
@@ -76,7 +78,7 @@ func TestDiagnostic(t *testing.T) {
 				Character: 3,
 			},
 		},
-		Code: "foo/bar",
+		Code: NewStringDiagnosticCode("foo/bar"),
 		CodeDescription: &CodeDescription{
 			Href: uri.File("/path/to/test.go"),
 		},
@@ -151,7 +153,7 @@ func TestDiagnostic(t *testing.T) {
 			},
 		},
 		Severity: DiagnosticSeverityError,
-		Code:     "foo/bar",
+		Code:     NewStringDiagnosticCode("foo/bar"),
 		CodeDescription: &CodeDescription{
 			Href: uri.File("/path/to/test.go"),
 		},
@@ -314,6 +316,190 @@ func TestDiagnostic(t *testing.T) {
 	})
 }
 
+func TestDiagnosticCode(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantInt    = `1`
+		wantString = `"1"`
+	)
+	wantTypeInt := *NewIntDiagnosticCode(1)
+	wantTypeString := *NewStringDiagnosticCode("1")
+	float, outOfRange := json.Number("1.5"), json.Number("3000000000")
+	wantTypeFloat := DiagnosticCode{NumberValue: &float}
+	wantTypeOutOfRange := DiagnosticCode{NumberValue: &outOfRange}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          DiagnosticCode
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Int",
+				field:          wantTypeInt,
+				want:           wantInt,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "String",
+				field:          wantTypeString,
+				want:           wantString,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "OutOfRange",
+				field:          wantTypeOutOfRange,
+				want:           `3000000000`,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "InvalidInt",
+				field:          wantTypeInt,
+				want:           wantString,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+			{
+				name:           "InvalidEmpty",
+				field:          DiagnosticCode{},
+				want:           "",
+				wantMarshalErr: true,
+				wantErr:        false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             DiagnosticCode
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Int",
+				field:            wantInt,
+				want:             wantTypeInt,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "String",
+				field:            wantString,
+				want:             wantTypeString,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "InvalidInt",
+				field:            wantInt,
+				want:             wantTypeString,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+			{
+				name:             "Float",
+				field:            `1.5`,
+				want:             wantTypeFloat,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "OutOfRange",
+				field:            ` 3000000000 `,
+				want:             wantTypeOutOfRange,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "InvalidBool",
+				field:            `true`,
+				want:             DiagnosticCode{},
+				wantUnmarshalErr: true,
+				wantErr:          false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got DiagnosticCode
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("DiagnosticOutOfRange", func(t *testing.T) {
+		t.Parallel()
+
+		var got Diagnostic
+		data := `{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":1}},"code":3000000000,"message":"foo"}`
+		if err := json.Unmarshal([]byte(data), &got); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(&wantTypeOutOfRange, got.Code); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+
+		roundTrip, err := json.Marshal(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(data, string(roundTrip)); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+
+		if got := NewIntDiagnosticCode(-42).String(); got != "-42" {
+			t.Errorf("String() = %q, want %q", got, "-42")
+		}
+		if got := wantTypeOutOfRange.String(); got != "3000000000" {
+			t.Errorf("String() = %q, want %q", got, "3000000000")
+		}
+		if got := NewStringDiagnosticCode("foo/bar").String(); got != "foo/bar" {
+			t.Errorf("String() = %q, want %q", got, "foo/bar")
+		}
+	})
+}
+
 func TestDiagnosticSeverity_String(t *testing.T) {
 	t.Parallel()
 
@@ -528,7 +714,7 @@ func TestPublishDiagnosticsParams(t *testing.T) {
 					},
 				},
 				Severity: DiagnosticSeverityError,
-				Code:     "foo/bar",
+				Code:     NewStringDiagnosticCode("foo/bar"),
 				Source:   "test foo bar",
 				Message:  "foo bar",
 				RelatedInformation: []DiagnosticRelatedInformation{
@@ -638,3 +824,636 @@ func TestPublishDiagnosticsParams(t *testing.T) {
 		}
 	})
 }
+
+func TestDocumentDiagnosticParams(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantWorkDoneToken      = "156edea9-9d8d-422f-b7ee-81a84594afbb"
+		wantPartialResultToken = "dd134d84-c134-4d7a-a2a3-f8af3ef4a568"
+	)
+	const (
+		want        = `{"workDoneToken":"` + wantWorkDoneToken + `","partialResultToken":"` + wantPartialResultToken + `","textDocument":{"uri":"file:///path/to/basic.go"},"identifier":"testIdentifier","previousResultId":"testResultID"}`
+		wantNil     = `{"textDocument":{"uri":"file:///path/to/basic.go"}}`
+		wantInvalid = `{"workDoneToken":"` + wantPartialResultToken + `","partialResultToken":"` + wantWorkDoneToken + `","textDocument":{"uri":"file:///path/to/basic_gen.go"},"identifier":"invalidIdentifier","previousResultId":"invalidResultID"}`
+	)
+	wantType := DocumentDiagnosticParams{
+		WorkDoneProgressParams: WorkDoneProgressParams{
+			WorkDoneToken: NewProgressToken(wantWorkDoneToken),
+		},
+		PartialResultParams: PartialResultParams{
+			PartialResultToken: NewProgressToken(wantPartialResultToken),
+		},
+		TextDocument: TextDocumentIdentifier{
+			URI: uri.File("/path/to/basic.go"),
+		},
+		Identifier:       "testIdentifier",
+		PreviousResultID: "testResultID",
+	}
+	wantTypeNil := DocumentDiagnosticParams{
+		TextDocument: TextDocumentIdentifier{
+			URI: uri.File("/path/to/basic.go"),
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          DocumentDiagnosticParams
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Valid",
+				field:          wantType,
+				want:           want,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Nil",
+				field:          wantTypeNil,
+				want:           wantNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantType,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             DocumentDiagnosticParams
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Valid",
+				field:            want,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Nil",
+				field:            wantNil,
+				want:             wantTypeNil,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got DocumentDiagnosticParams
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreTypes(WorkDoneProgressParams{}, PartialResultParams{})); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+
+				if workDoneToken := got.WorkDoneToken; workDoneToken != nil {
+					if diff := cmp.Diff(fmt.Sprint(workDoneToken), wantWorkDoneToken); (diff != "") != tt.wantErr {
+						t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+					}
+				}
+
+				if partialResultToken := got.PartialResultToken; partialResultToken != nil {
+					if diff := cmp.Diff(fmt.Sprint(partialResultToken), wantPartialResultToken); (diff != "") != tt.wantErr {
+						t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+					}
+				}
+			})
+		}
+	})
+}
+
+func TestDocumentDiagnosticReport(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantFull      = `{"kind":"full","resultId":"testResultID","items":[{"range":{"start":{"line":25,"character":1},"end":{"line":27,"character":3}},"severity":1,"message":"foo bar"}],"relatedDocuments":{"file:///path/to/basic_gen.go":{"kind":"unchanged","resultId":"relatedResultID"}}}`
+		wantFullNil   = `{"kind":"full","items":[]}`
+		wantUnchanged = `{"kind":"unchanged","resultId":"testResultID"}`
+		wantInvalid   = `{"kind":"unknown","resultId":"testResultID"}`
+	)
+	wantTypeFull := DocumentDiagnosticReport{
+		Full: &RelatedFullDocumentDiagnosticReport{
+			FullDocumentDiagnosticReport: FullDocumentDiagnosticReport{
+				Kind:     DocumentDiagnosticReportKindFull,
+				ResultID: "testResultID",
+				Items: []Diagnostic{
+					{
+						Range: Range{
+							Start: Position{
+								Line:      25,
+								Character: 1,
+							},
+							End: Position{
+								Line:      27,
+								Character: 3,
+							},
+						},
+						Severity: DiagnosticSeverityError,
+						Message:  "foo bar",
+					},
+				},
+			},
+			RelatedDocuments: map[DocumentURI]DocumentDiagnosticReportItem{
+				DocumentURI("file:///path/to/basic_gen.go"): {
+					Unchanged: &UnchangedDocumentDiagnosticReport{
+						Kind:     DocumentDiagnosticReportKindUnchanged,
+						ResultID: "relatedResultID",
+					},
+				},
+			},
+		},
+	}
+	wantTypeFullNil := DocumentDiagnosticReport{
+		Full: &RelatedFullDocumentDiagnosticReport{
+			FullDocumentDiagnosticReport: FullDocumentDiagnosticReport{
+				Kind:  DocumentDiagnosticReportKindFull,
+				Items: []Diagnostic{},
+			},
+		},
+	}
+	wantTypeUnchanged := DocumentDiagnosticReport{
+		Unchanged: &RelatedUnchangedDocumentDiagnosticReport{
+			UnchangedDocumentDiagnosticReport: UnchangedDocumentDiagnosticReport{
+				Kind:     DocumentDiagnosticReportKindUnchanged,
+				ResultID: "testResultID",
+			},
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          DocumentDiagnosticReport
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Full",
+				field:          wantTypeFull,
+				want:           wantFull,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name: "FullNilItems",
+				field: DocumentDiagnosticReport{
+					Full: &RelatedFullDocumentDiagnosticReport{},
+				},
+				want:           wantFullNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Unchanged",
+				field:          wantTypeUnchanged,
+				want:           wantUnchanged,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Empty",
+				field:          DocumentDiagnosticReport{},
+				want:           "",
+				wantMarshalErr: true,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantTypeUnchanged,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             DocumentDiagnosticReport
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Full",
+				field:            wantFull,
+				want:             wantTypeFull,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "FullNilItems",
+				field:            wantFullNil,
+				want:             wantTypeFullNil,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Unchanged",
+				field:            wantUnchanged,
+				want:             wantTypeUnchanged,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             DocumentDiagnosticReport{},
+				wantUnmarshalErr: true,
+				wantErr:          false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got DocumentDiagnosticReport
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+}
+
+func TestWorkspaceDiagnosticParams(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantWorkDoneToken      = "156edea9-9d8d-422f-b7ee-81a84594afbb"
+		wantPartialResultToken = "dd134d84-c134-4d7a-a2a3-f8af3ef4a568"
+	)
+	const (
+		want        = `{"workDoneToken":"` + wantWorkDoneToken + `","partialResultToken":"` + wantPartialResultToken + `","identifier":"testIdentifier","previousResultIds":[{"uri":"file:///path/to/basic.go","value":"testResultID"}]}`
+		wantNil     = `{"previousResultIds":[]}`
+		wantInvalid = `{"workDoneToken":"` + wantPartialResultToken + `","partialResultToken":"` + wantWorkDoneToken + `","identifier":"invalidIdentifier","previousResultIds":[{"uri":"file:///path/to/basic_gen.go","value":"invalidResultID"}]}`
+	)
+	wantType := WorkspaceDiagnosticParams{
+		WorkDoneProgressParams: WorkDoneProgressParams{
+			WorkDoneToken: NewProgressToken(wantWorkDoneToken),
+		},
+		PartialResultParams: PartialResultParams{
+			PartialResultToken: NewProgressToken(wantPartialResultToken),
+		},
+		Identifier: "testIdentifier",
+		PreviousResultIDs: []PreviousResultID{
+			{
+				URI:   uri.File("/path/to/basic.go"),
+				Value: "testResultID",
+			},
+		},
+	}
+	wantTypeNil := WorkspaceDiagnosticParams{
+		PreviousResultIDs: []PreviousResultID{},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          WorkspaceDiagnosticParams
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Valid",
+				field:          wantType,
+				want:           want,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Nil",
+				field:          wantTypeNil,
+				want:           wantNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "NilPreviousResultIDs",
+				field:          WorkspaceDiagnosticParams{},
+				want:           wantNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantType,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             WorkspaceDiagnosticParams
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Valid",
+				field:            want,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Nil",
+				field:            wantNil,
+				want:             wantTypeNil,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got WorkspaceDiagnosticParams
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreTypes(WorkDoneProgressParams{}, PartialResultParams{})); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+
+				if workDoneToken := got.WorkDoneToken; workDoneToken != nil {
+					if diff := cmp.Diff(fmt.Sprint(workDoneToken), wantWorkDoneToken); (diff != "") != tt.wantErr {
+						t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+					}
+				}
+
+				if partialResultToken := got.PartialResultToken; partialResultToken != nil {
+					if diff := cmp.Diff(fmt.Sprint(partialResultToken), wantPartialResultToken); (diff != "") != tt.wantErr {
+						t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+					}
+				}
+			})
+		}
+	})
+}
+
+func TestWorkspaceDiagnosticReport(t *testing.T) {
+	t.Parallel()
+
+	const (
+		want        = `{"items":[{"kind":"full","resultId":"testResultID","items":[{"range":{"start":{"line":25,"character":1},"end":{"line":27,"character":3}},"message":"foo bar"}],"uri":"file:///path/to/basic.go","version":1},{"kind":"unchanged","resultId":"unchangedResultID","uri":"file:///path/to/basic_gen.go","version":null}]}`
+		wantNil     = `{"items":[]}`
+		wantInvalid = `{"items":[{"kind":"unknown","resultId":"testResultID","uri":"file:///path/to/basic.go","version":1}]}`
+	)
+	version := int32(1)
+	wantType := WorkspaceDiagnosticReport{
+		Items: []WorkspaceDocumentDiagnosticReport{
+			{
+				Full: &WorkspaceFullDocumentDiagnosticReport{
+					FullDocumentDiagnosticReport: FullDocumentDiagnosticReport{
+						Kind:     DocumentDiagnosticReportKindFull,
+						ResultID: "testResultID",
+						Items: []Diagnostic{
+							{
+								Range: Range{
+									Start: Position{
+										Line:      25,
+										Character: 1,
+									},
+									End: Position{
+										Line:      27,
+										Character: 3,
+									},
+								},
+								Message: "foo bar",
+							},
+						},
+					},
+					URI:     uri.File("/path/to/basic.go"),
+					Version: &version,
+				},
+			},
+			{
+				Unchanged: &WorkspaceUnchangedDocumentDiagnosticReport{
+					UnchangedDocumentDiagnosticReport: UnchangedDocumentDiagnosticReport{
+						Kind:     DocumentDiagnosticReportKindUnchanged,
+						ResultID: "unchangedResultID",
+					},
+					URI: uri.File("/path/to/basic_gen.go"),
+				},
+			},
+		},
+	}
+	wantTypeNil := WorkspaceDiagnosticReport{
+		Items: []WorkspaceDocumentDiagnosticReport{},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          WorkspaceDiagnosticReport
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Valid",
+				field:          wantType,
+				want:           want,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Nil",
+				field:          wantTypeNil,
+				want:           wantNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantType,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             WorkspaceDiagnosticReport
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Valid",
+				field:            want,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Nil",
+				field:            wantNil,
+				want:             wantTypeNil,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             WorkspaceDiagnosticReport{},
+				wantUnmarshalErr: true,
+				wantErr:          false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got WorkspaceDiagnosticReport
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if tt.wantUnmarshalErr {
+					return
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultDiffBudget is the budget DiffTextEdits uses when it is given none.
+const DefaultDiffBudget = 1 << 22
+
+// DiffTextEdits returns the edits that turn before into after.
+//
+// The texts are compared line by line first, and every changed block of lines is then refined to the changed
+// characters, so that the edits touch as little of the document as possible. The Character of every Position counts
+// code units of enc; an empty enc counts UTF-16 code units. The edits are sorted and do not overlap.
+//
+// budget bounds the number of steps spent comparing the texts and so the time and memory used on huge or very
+// different documents; a budget <= 0 uses DefaultDiffBudget. Once it is exhausted, a changed block is no longer
+// refined and is replaced as a whole, and if even the line comparison runs out, everything between the common
+// leading and trailing lines is replaced by a single edit.
+func DiffTextEdits(before, after string, enc PositionEncodingKind, budget int) []TextEdit {
+	if before == after {
+		return nil
+	}
+	if budget <= 0 {
+		budget = DefaultDiffBudget
+	}
+
+	a, b := splitLines(before), splitLines(after)
+	offsets := make([]int, len(a)+1)
+	for i, line := range a {
+		offsets[i+1] = offsets[i] + len(line)
+	}
+
+	hunks, ok := diffTokens(a, b, &budget)
+	if !ok {
+		// too expensive: replace everything between the common leading and trailing lines.
+		hunks = []diffHunk{trimCommon(a, b)}
+	}
+
+	index := NewLineIndex(before)
+	var edits []TextEdit
+	for _, h := range hunks {
+		start, end := offsets[h.aStart], offsets[h.aEnd]
+		newText := strings.Join(b[h.bStart:h.bEnd], "")
+		if h.aStart == h.aEnd || h.bStart == h.bEnd {
+			// whole lines were inserted or deleted: there is nothing to refine.
+			edits = append(edits, newDiffEdit(index, start, end, newText, enc))
+			continue
+		}
+
+		x, y := splitChars(before[start:end]), splitChars(newText)
+		refined, ok := diffTokens(x, y, &budget)
+		if !ok {
+			edits = append(edits, newDiffEdit(index, start, end, newText, enc))
+			continue
+		}
+		xOffsets := make([]int, len(x)+1)
+		for i, c := range x {
+			xOffsets[i+1] = xOffsets[i] + len(c)
+		}
+		for _, r := range refined {
+			edits = append(edits, newDiffEdit(index, start+xOffsets[r.aStart], start+xOffsets[r.aEnd],
+				strings.Join(y[r.bStart:r.bEnd], ""), enc))
+		}
+	}
+
+	return edits
+}
+
+// newDiffEdit returns the TextEdit replacing the bytes from start to end of the text of index with newText.
+func newDiffEdit(index *LineIndex, start, end int, newText string, enc PositionEncodingKind) TextEdit {
+	return TextEdit{
+		Range: Range{
+			Start: index.Position(start, enc),
+			End:   index.Position(end, enc),
+		},
+		NewText: newText,
+	}
+}
+
+// splitLines splits text after every line terminator. The terminators are kept, so joining the lines returns text.
+func splitLines(text string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\r':
+			if i+1 < len(text) && text[i+1] == '\n' {
+				i++
+			}
+			fallthrough
+		case '\n':
+			lines = append(lines, text[start:i+1])
+			start = i + 1
+		}
+	}
+	if start < len(text) {
+		lines = append(lines, text[start:])
+	}
+
+	return lines
+}
+
+// splitChars splits text into its runes. A "\r\n" line terminator stays in one piece, so that no edit ever
+// starts or ends between its two characters, which a Position cannot express.
+func splitChars(text string) []string {
+	chars := make([]string, 0, len(text))
+	for i := 0; i < len(text); {
+		_, size := utf8.DecodeRuneInString(text[i:])
+		if text[i] == '\r' && i+1 < len(text) && text[i+1] == '\n' {
+			size = 2
+		}
+		chars = append(chars, text[i:i+size])
+		i += size
+	}
+
+	return chars
+}
+
+// diffHunk is a block of tokens a[aStart:aEnd] replaced by b[bStart:bEnd].
+type diffHunk struct {
+	aStart, aEnd int
+	bStart, bEnd int
+}
+
+// trimCommon returns the hunk replacing everything but the common prefix and suffix of a and b.
+func trimCommon(a, b []string) diffHunk {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	return diffHunk{
+		aStart: prefix,
+		aEnd:   len(a) - suffix,
+		bStart: prefix,
+		bEnd:   len(b) - suffix,
+	}
+}
+
+// diffTokens returns the hunks of a shortest edit script turning a into b, using the O(ND) algorithm of
+// Eugene W. Myers.
+//
+// Every step of the algorithm is taken from budget. diffTokens reports false if the budget runs out.
+func diffTokens(a, b []string, budget *int) ([]diffHunk, bool) {
+	common := trimCommon(a, b)
+	prefix := common.aStart
+	a, b = a[prefix:common.aEnd], b[prefix:common.bEnd]
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil, true
+	}
+
+	// v[k+size] is the furthest x reached on diagonal k; trace[d] holds v[-d..d] after d differences.
+	size := n + m
+	v := make([]int, 2*size+2)
+	var trace [][]int
+	for d := 0; d <= size; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[k-1+size] < v[k+1+size]) {
+				x = v[k+1+size]
+			} else {
+				x = v[k-1+size] + 1
+			}
+			y := x - k
+			snake := x
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k+size] = x
+
+			// one step for the move plus one for every token of the snake.
+			*budget -= 1 + x - snake
+			if *budget < 0 {
+				return nil, false
+			}
+
+			if x >= n && y >= m {
+				return backtrack(trace, n, m, d, prefix), true
+			}
+		}
+		trace = append(trace, append([]int(nil), v[size-d:size+d+1]...))
+	}
+
+	return nil, false // unreachable: d == n+m always reaches the end.
+}
+
+// backtrack walks the trace of diffTokens back from (n, m) and collects the hunks, shifted by offset.
+func backtrack(trace [][]int, n, m, d, offset int) []diffHunk {
+	var hunks []diffHunk
+	x, y := n, m
+	for ; d > 0; d-- {
+		prev := trace[d-1]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := prev[prevK+d-1]
+		prevY := prevX - prevK
+
+		// a move down inserts b[prevY], a move right deletes a[prevX].
+		h := diffHunk{aStart: prevX + offset, aEnd: prevX + offset, bStart: prevY + offset, bEnd: prevY + 1 + offset}
+		if prevK == k-1 {
+			h.aEnd, h.bEnd = prevX+1+offset, prevY+offset
+		}
+		if last := len(hunks) - 1; last >= 0 && hunks[last].aStart == h.aEnd && hunks[last].bStart == h.bEnd {
+			hunks[last].aStart, hunks[last].bStart = h.aStart, h.bStart
+		} else {
+			hunks = append(hunks, h)
+		}
+		x, y = prevX, prevY
+	}
+
+	// reverse into document order.
+	for i, j := 0, len(hunks)-1; i < j; i, j = i+1, j-1 {
+		hunks[i], hunks[j] = hunks[j], hunks[i]
+	}
+
+	return hunks
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffTextEdits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		enc    PositionEncodingKind
+		budget int
+		want   []TextEdit
+	}{
+		{
+			name:   "Equal",
+			before: "package main\n",
+			after:  "package main\n",
+			want:   nil,
+		},
+		{
+			name:   "InsertLine",
+			before: "a\nc\n",
+			after:  "a\nb\nc\n",
+			want:   []TextEdit{newTextEdit(1, 0, 1, 0, "b\n")},
+		},
+		{
+			name:   "DeleteLine",
+			before: "a\nb\nc\n",
+			after:  "a\nc\n",
+			want:   []TextEdit{newTextEdit(1, 0, 2, 0, "")},
+		},
+		{
+			name:   "ChangeCharacters",
+			before: "package main\n\nfunc main() {}\n",
+			after:  "package main\n\nfunc mein() {}\n",
+			want:   []TextEdit{newTextEdit(2, 6, 2, 7, "e")},
+		},
+		{
+			name:   "SeveralHunks",
+			before: "a\nfoo\nb\nc\nbar\ne\n",
+			after:  "a\nfooo\nb\nc\nbr\ne\nd\n",
+			want: []TextEdit{
+				newTextEdit(1, 3, 1, 3, "o"),
+				newTextEdit(4, 1, 4, 2, ""),
+				newTextEdit(6, 0, 6, 0, "d\n"),
+			},
+		},
+		{
+			name:   "EmptyBefore",
+			before: "",
+			after:  "a\nb",
+			want:   []TextEdit{newTextEdit(0, 0, 0, 0, "a\nb")},
+		},
+		{
+			name:   "EmptyAfter",
+			before: "a\nb",
+			after:  "",
+			want:   []TextEdit{newTextEdit(0, 0, 1, 1, "")},
+		},
+		{
+			name:   "LineEndings",
+			before: "a\r\nb\n",
+			after:  "a\nb\r\n",
+			want: []TextEdit{
+				newTextEdit(0, 1, 1, 1, ""),
+				newTextEdit(2, 0, 2, 0, "b\r\n"),
+			},
+		},
+		{
+			name:   "UTF16",
+			before: "x := \"𝔣oo\"\n",
+			after:  "x := \"𝔣o\"\n",
+			enc:    PositionEncodingKindUTF16,
+			want:   []TextEdit{newTextEdit(0, 9, 0, 10, "")},
+		},
+		{
+			name:   "UTF8",
+			before: "x := \"𝔣oo\"\n",
+			after:  "x := \"𝔣o\"\n",
+			enc:    PositionEncodingKindUTF8,
+			want:   []TextEdit{newTextEdit(0, 11, 0, 12, "")},
+		},
+		{
+			name:   "BudgetSkipsRefinement",
+			before: "a\nfoo\nb\n",
+			after:  "a\nfao\nb\n",
+			budget: 3,
+			want:   []TextEdit{newTextEdit(1, 0, 2, 0, "fao\n")},
+		},
+		{
+			name:   "BudgetSkipsLines",
+			before: "a\nb\nc\nd\ne\n",
+			after:  "a\nB\nc\nD\ne\n",
+			budget: 1,
+			want:   []TextEdit{newTextEdit(1, 0, 4, 0, "B\nc\nD\n")},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := DiffTextEdits(tt.before, tt.after, tt.enc, tt.budget)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got)\n%s", diff)
+			}
+
+			applied, _, err := ApplyTextEdits(tt.before, got, tt.enc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if applied != tt.after {
+				t.Errorf("applying edits = %q, want %q", applied, tt.after)
+			}
+		})
+	}
+}
+
+func TestDiffTextEdits_Random(t *testing.T) {
+	t.Parallel()
+
+	pieces := []string{"a", "b", "é", "𝔣", " ", "\n", "\r\n", "\r", "func", "}"}
+	random := func(r *rand.Rand) string {
+		var b strings.Builder
+		for i := r.Intn(40); i > 0; i-- {
+			b.WriteString(pieces[r.Intn(len(pieces))])
+		}
+		return b.String()
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		before, after := random(r), random(r)
+		for _, budget := range []int{0, 10} {
+			edits := DiffTextEdits(before, after, PositionEncodingKindUTF16, budget)
+			got, _, err := ApplyTextEdits(before, edits, PositionEncodingKindUTF16)
+			if err != nil {
+				t.Fatalf("DiffTextEdits(%q, %q, %d): %v", before, after, budget, err)
+			}
+			if got != after {
+				t.Fatalf("DiffTextEdits(%q, %q, %d) applied = %q", before, after, budget, got)
+			}
+		}
+	}
+}
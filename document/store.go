@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package document keeps track of the text documents a client has opened on the server.
+package document // import "go.lsp.dev/protocol/document"
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.lsp.dev/protocol"
+)
+
+var (
+	// ErrNotOpen is returned when a notification refers to a document that is not open.
+	ErrNotOpen = errors.New("document is not open")
+
+	// ErrAlreadyOpen is returned when a document is opened twice without being closed in between.
+	ErrAlreadyOpen = errors.New("document is already open")
+)
+
+// VersionError is returned when a change does not increase the version of a document.
+type VersionError struct {
+	// URI is the URI of the document.
+	URI protocol.DocumentURI
+
+	// Current is the version of the document in the Store.
+	Current int32
+
+	// Version is the rejected version.
+	Version int32
+}
+
+// Error implements error.
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("out of order version %d for %s: current version is %d", e.Version, e.URI, e.Current)
+}
+
+// Snapshot is an immutable state of an open text document.
+type Snapshot struct {
+	uri        protocol.DocumentURI
+	languageID protocol.LanguageIdentifier
+	version    int32
+	index      *protocol.LineIndex
+}
+
+// URI returns the URI of the document.
+func (s *Snapshot) URI() protocol.DocumentURI {
+	return s.uri
+}
+
+// LanguageID returns the language identifier of the document.
+func (s *Snapshot) LanguageID() protocol.LanguageIdentifier {
+	return s.languageID
+}
+
+// Version returns the version of the document.
+func (s *Snapshot) Version() int32 {
+	return s.version
+}
+
+// Text returns the content of the document.
+func (s *Snapshot) Text() string {
+	return s.index.Text()
+}
+
+// LineIndex returns the line index of the content of the document.
+func (s *Snapshot) LineIndex() *protocol.LineIndex {
+	return s.index
+}
+
+// Store is a set of open text documents keyed by DocumentURI.
+//
+// A Store is safe for concurrent use. The zero value is an empty Store ready to use that counts the characters of
+// positions in UTF-16 code units.
+type Store struct {
+	mu   sync.RWMutex
+	docs map[protocol.DocumentURI]*Snapshot
+	enc  protocol.PositionEncodingKind
+}
+
+// NewStore returns an empty Store whose positions count characters in code units of enc, the
+// PositionEncodingKind negotiated with the client. An empty enc counts UTF-16 code units.
+func NewStore(enc protocol.PositionEncodingKind) *Store {
+	return &Store{
+		docs: make(map[protocol.DocumentURI]*Snapshot),
+		enc:  enc,
+	}
+}
+
+// Open adds the document of a textDocument/didOpen notification to the store.
+func (s *Store) Open(params *protocol.DidOpenTextDocumentParams) (*Snapshot, error) {
+	doc := params.TextDocument
+	snapshot := &Snapshot{
+		uri:        doc.URI,
+		languageID: doc.LanguageID,
+		version:    doc.Version,
+		index:      protocol.NewLineIndex(doc.Text),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.docs[doc.URI]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyOpen, doc.URI)
+	}
+	if s.docs == nil {
+		s.docs = make(map[protocol.DocumentURI]*Snapshot)
+	}
+	s.docs[doc.URI] = snapshot
+
+	return snapshot, nil
+}
+
+// Change applies the content changes of a textDocument/didChange notification in order.
+//
+// The changes are applied atomically: if the version does not increase or a change is invalid, the document is left
+// untouched. A version that does not increase is reported as a *VersionError.
+func (s *Store) Change(params *protocol.DidChangeTextDocumentParams) (*Snapshot, error) {
+	uri := params.TextDocument.URI
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.docs[uri]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotOpen, uri)
+	}
+	if version := params.TextDocument.Version; version <= current.version {
+		return nil, &VersionError{
+			URI:     uri,
+			Current: current.version,
+			Version: version,
+		}
+	}
+
+	index := current.index
+	for i, change := range params.ContentChanges {
+		text, err := apply(index, change, s.enc)
+		if err != nil {
+			return nil, fmt.Errorf("contentChanges[%d]: %w", i, err)
+		}
+		index = protocol.NewLineIndex(text)
+	}
+
+	snapshot := &Snapshot{
+		uri:        uri,
+		languageID: current.languageID,
+		version:    params.TextDocument.Version,
+		index:      index,
+	}
+	s.docs[uri] = snapshot
+
+	return snapshot, nil
+}
+
+// Close removes the document of a textDocument/didClose notification from the store.
+func (s *Store) Close(params *protocol.DidCloseTextDocumentParams) error {
+	uri := params.TextDocument.URI
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.docs[uri]; !ok {
+		return fmt.Errorf("%w: %s", ErrNotOpen, uri)
+	}
+	delete(s.docs, uri)
+
+	return nil
+}
+
+// Get returns the current snapshot of the document identified by uri.
+func (s *Store) Get(uri protocol.DocumentURI) (*Snapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshot, ok := s.docs[uri]
+
+	return snapshot, ok
+}
+
+// URIs returns the URIs of the open documents in no particular order.
+func (s *Store) URIs() []protocol.DocumentURI {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	uris := make([]protocol.DocumentURI, 0, len(s.docs))
+	for uri := range s.docs {
+		uris = append(uris, uri)
+	}
+
+	return uris
+}
+
+// apply returns the text of index after change, whose positions count characters in code units of enc.
+func apply(index *protocol.LineIndex, change protocol.TextDocumentContentChangeEvent, enc protocol.PositionEncodingKind) (string, error) {
+	if change.IsFull() {
+		return change.Text, nil
+	}
+
+	start, end := index.Offset(change.Range.Start, enc), index.Offset(change.Range.End, enc)
+	if start > end {
+		return "", fmt.Errorf("range start %d:%d is after end %d:%d",
+			change.Range.Start.Line, change.Range.Start.Character, change.Range.End.Line, change.Range.End.Character)
+	}
+	text := index.Text()
+
+	return text[:start] + change.Text + text[end:], nil
+}
@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package document
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+)
+
+const testURI = protocol.DocumentURI("file:///path/to/main.go")
+
+func openParams(text string, version int32) *protocol.DidOpenTextDocumentParams {
+	return &protocol.DidOpenTextDocumentParams{
+		TextDocument: protocol.TextDocumentItem{
+			URI:        testURI,
+			LanguageID: protocol.GoLanguage,
+			Version:    version,
+			Text:       text,
+		},
+	}
+}
+
+func changeParams(version int32, changes ...protocol.TextDocumentContentChangeEvent) *protocol.DidChangeTextDocumentParams {
+	return &protocol.DidChangeTextDocumentParams{
+		TextDocument: protocol.VersionedTextDocumentIdentifier{
+			TextDocumentIdentifier: protocol.TextDocumentIdentifier{
+				URI: testURI,
+			},
+			Version: version,
+		},
+		ContentChanges: changes,
+	}
+}
+
+func rangeChange(startLine, startChar, endLine, endChar uint32, text string) protocol.TextDocumentContentChangeEvent {
+	return protocol.TextDocumentContentChangeEvent{
+		Range: &protocol.Range{
+			Start: protocol.Position{Line: startLine, Character: startChar},
+			End:   protocol.Position{Line: endLine, Character: endChar},
+		},
+		Text: text,
+	}
+}
+
+func TestStore_Change(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		enc     protocol.PositionEncodingKind
+		text    string
+		changes []protocol.TextDocumentContentChangeEvent
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Full",
+			text:    "package main\n",
+			changes: []protocol.TextDocumentContentChangeEvent{{Text: "package foo\n"}},
+			want:    "package foo\n",
+		},
+		{
+			name:    "Incremental",
+			text:    "package main\n",
+			changes: []protocol.TextDocumentContentChangeEvent{rangeChange(0, 8, 0, 12, "foo")},
+			want:    "package foo\n",
+		},
+		{
+			name: "InOrder",
+			text: "a\r\nb\rc\n",
+			changes: []protocol.TextDocumentContentChangeEvent{
+				rangeChange(1, 0, 1, 1, "B"),
+				rangeChange(0, 1, 1, 0, "\n"),
+				rangeChange(2, 1, 2, 1, "C"),
+			},
+			want: "a\nB\rcC\n",
+		},
+		{
+			name: "FullThenIncremental",
+			text: "package main\n",
+			changes: []protocol.TextDocumentContentChangeEvent{
+				{Text: "x"},
+				rangeChange(0, 1, 0, 1, "y"),
+			},
+			want: "xy",
+		},
+		{
+			name:    "SurrogatePair",
+			text:    "a𝔣b",
+			changes: []protocol.TextDocumentContentChangeEvent{rangeChange(0, 1, 0, 3, "f")},
+			want:    "afb",
+		},
+		{
+			name:    "UTF8",
+			enc:     protocol.PositionEncodingKindUTF8,
+			text:    "a𝔣b",
+			changes: []protocol.TextDocumentContentChangeEvent{rangeChange(0, 1, 0, 5, "f")},
+			want:    "afb",
+		},
+		{
+			name:    "UTF32",
+			enc:     protocol.PositionEncodingKindUTF32,
+			text:    "a𝔣b",
+			changes: []protocol.TextDocumentContentChangeEvent{rangeChange(0, 1, 0, 2, "f")},
+			want:    "afb",
+		},
+		{
+			name:    "ClampPastEnd",
+			text:    "ab\ncd",
+			changes: []protocol.TextDocumentContentChangeEvent{rangeChange(0, 10, 9, 0, "!")},
+			want:    "ab!",
+		},
+		{
+			name:    "ReversedRange",
+			text:    "package main\n",
+			changes: []protocol.TextDocumentContentChangeEvent{rangeChange(0, 12, 0, 8, "foo")},
+			want:    "package main\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewStore(tt.enc)
+			if _, err := s.Open(openParams(tt.text, 1)); err != nil {
+				t.Fatal(err)
+			}
+
+			snapshot, err := s.Change(changeParams(2, tt.changes...))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Change() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				snapshot, _ = s.Get(testURI)
+				if snapshot.Version() != 1 {
+					t.Errorf("Version() = %d, want 1", snapshot.Version())
+				}
+			}
+
+			if got := snapshot.Text(); got != tt.want {
+				t.Errorf("Text() = %q, want %q", got, tt.want)
+			}
+			if got := snapshot.LineIndex().Text(); got != tt.want {
+				t.Errorf("LineIndex().Text() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStore_Version(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore(protocol.PositionEncodingKindUTF16)
+	opened, err := s.Open(openParams("package main\n", 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, version := range []int32{5, 4} {
+		_, err := s.Change(changeParams(version, protocol.TextDocumentContentChangeEvent{Text: "package foo\n"}))
+		var verr *VersionError
+		if !errors.As(err, &verr) {
+			t.Fatalf("Change(%d) error = %v, want *VersionError", version, err)
+		}
+		if verr.URI != testURI || verr.Current != 5 || verr.Version != version {
+			t.Errorf("Change(%d) error = %#v", version, verr)
+		}
+	}
+
+	changed, err := s.Change(changeParams(7, protocol.TextDocumentContentChangeEvent{Text: "package foo\n"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed.Version() != 7 || changed.LanguageID() != protocol.GoLanguage || changed.URI() != testURI {
+		t.Errorf("Change() = %d %s %s", changed.Version(), changed.LanguageID(), changed.URI())
+	}
+
+	// earlier snapshots are not affected by later changes.
+	if opened.Version() != 5 || opened.Text() != "package main\n" {
+		t.Errorf("opened snapshot changed to version %d: %q", opened.Version(), opened.Text())
+	}
+}
+
+func TestStore_OpenClose(t *testing.T) {
+	t.Parallel()
+
+	var s Store
+	if _, ok := s.Get(testURI); ok {
+		t.Fatal("Get() on an empty store must fail")
+	}
+	if _, err := s.Change(changeParams(1)); !errors.Is(err, ErrNotOpen) {
+		t.Errorf("Change() error = %v, want %v", err, ErrNotOpen)
+	}
+
+	if _, err := s.Open(openParams("", 1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Open(openParams("", 1)); !errors.Is(err, ErrAlreadyOpen) {
+		t.Errorf("Open() error = %v, want %v", err, ErrAlreadyOpen)
+	}
+	if uris := s.URIs(); len(uris) != 1 || uris[0] != testURI {
+		t.Errorf("URIs() = %v", uris)
+	}
+
+	closeParams := &protocol.DidCloseTextDocumentParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: testURI},
+	}
+	if err := s.Close(closeParams); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(closeParams); !errors.Is(err, ErrNotOpen) {
+		t.Errorf("Close() error = %v, want %v", err, ErrNotOpen)
+	}
+	if _, ok := s.Get(testURI); ok {
+		t.Error("Get() after Close() must fail")
+	}
+}
+
+func TestStore_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	const n = 16
+
+	s := NewStore(protocol.PositionEncodingKindUTF16)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			u := uri.File(fmt.Sprintf("/path/to/%d.go", i))
+			open := &protocol.DidOpenTextDocumentParams{
+				TextDocument: protocol.TextDocumentItem{URI: u, Version: 1},
+			}
+			if _, err := s.Open(open); err != nil {
+				t.Error(err)
+				return
+			}
+			for version := int32(2); version <= 10; version++ {
+				change := changeParams(version, rangeChange(0, 0, 0, 0, "x"))
+				change.TextDocument.URI = u
+				if _, err := s.Change(change); err != nil {
+					t.Error(err)
+					return
+				}
+				s.URIs()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		snapshot, ok := s.Get(uri.File(fmt.Sprintf("/path/to/%d.go", i)))
+		if !ok {
+			t.Fatalf("document %d is not open", i)
+		}
+		if snapshot.Text() != "xxxxxxxxx" || snapshot.Version() != 10 {
+			t.Errorf("document %d = %q at version %d", i, snapshot.Text(), snapshot.Version())
+		}
+	}
+}
@@ -84,6 +84,33 @@ type InitializeParams struct {
 	WorkspaceFolders []WorkspaceFolder `json:"workspaceFolders,omitempty"`
 }
 
+// PositionEncoding returns the position encoding negotiated between the client and the server.
+//
+// The supported encodings are the ones the server can work with, in the server's order of preference.
+// The first one which is also offered by the client via "general.positionEncodings" is returned.
+// If none of them is offered by the client, PositionEncodingKindUTF16 is returned since it's
+// the mandatory encoding every client supports.
+//
+// The result is meant to be reported back in ServerCapabilities.PositionEncoding.
+//
+// @since 3.17.0.
+func (p *InitializeParams) PositionEncoding(supported ...PositionEncodingKind) PositionEncodingKind {
+	general := p.Capabilities.General
+	if general == nil || len(general.PositionEncodings) == 0 {
+		return PositionEncodingKindUTF16
+	}
+
+	for _, kind := range supported {
+		for _, offered := range general.PositionEncodings {
+			if kind == offered {
+				return kind
+			}
+		}
+	}
+
+	return PositionEncodingKindUTF16
+}
+
 // InitializeResult result of ClientCapabilities.
 type InitializeResult struct {
 	// Capabilities is the capabilities the language server provides.
@@ -338,6 +338,65 @@ func TestInitializeParams(t *testing.T) {
 	})
 }
 
+func TestInitializeParams_PositionEncoding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		offered   []PositionEncodingKind
+		supported []PositionEncodingKind
+		want      PositionEncodingKind
+	}{
+		{
+			name:      "NotOffered",
+			offered:   nil,
+			supported: []PositionEncodingKind{PositionEncodingKindUTF8},
+			want:      PositionEncodingKindUTF16,
+		},
+		{
+			name:      "ServerPreference",
+			offered:   []PositionEncodingKind{PositionEncodingKindUTF16, PositionEncodingKindUTF8},
+			supported: []PositionEncodingKind{PositionEncodingKindUTF8, PositionEncodingKindUTF16},
+			want:      PositionEncodingKindUTF8,
+		},
+		{
+			name:      "FirstCommon",
+			offered:   []PositionEncodingKind{PositionEncodingKindUTF32, PositionEncodingKindUTF16},
+			supported: []PositionEncodingKind{PositionEncodingKindUTF8, PositionEncodingKindUTF32},
+			want:      PositionEncodingKindUTF32,
+		},
+		{
+			name:      "NoCommon",
+			offered:   []PositionEncodingKind{PositionEncodingKindUTF32},
+			supported: []PositionEncodingKind{PositionEncodingKindUTF8},
+			want:      PositionEncodingKindUTF16,
+		},
+		{
+			name:      "NoSupported",
+			offered:   []PositionEncodingKind{PositionEncodingKindUTF8},
+			supported: nil,
+			want:      PositionEncodingKindUTF16,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params := &InitializeParams{}
+			if tt.offered != nil {
+				params.Capabilities.General = &GeneralClientCapabilities{
+					PositionEncodings: tt.offered,
+				}
+			}
+
+			if got := params.PositionEncoding(tt.supported...); got != tt.want {
+				t.Errorf("InitializeParams.PositionEncoding(%v) = %v, want %v", tt.supported, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestLogTraceParams(t *testing.T) {
 	t.Parallel()
 
@@ -3767,41 +3826,41 @@ func TestInitializeResult(t *testing.T) {
 	)
 	wantType := InitializeResult{
 		Capabilities: ServerCapabilities{
-			TextDocumentSync: float64(1),
+			TextDocumentSync: &TextDocumentSync{Kind: TextDocumentSyncKindFull},
 			CompletionProvider: &CompletionOptions{
 				ResolveProvider:   true,
 				TriggerCharacters: []string{"Tab"},
 			},
-			HoverProvider: true,
+			HoverProvider: &HoverProvider{Value: true},
 			SignatureHelpProvider: &SignatureHelpOptions{
 				TriggerCharacters:   []string{"C-K"},
 				RetriggerCharacters: []string{"."},
 			},
-			DeclarationProvider:       true,
-			DefinitionProvider:        true,
-			TypeDefinitionProvider:    true,
-			ImplementationProvider:    true,
-			ReferencesProvider:        true,
-			DocumentHighlightProvider: true,
-			DocumentSymbolProvider:    true,
-			WorkspaceSymbolProvider:   true,
-			CodeActionProvider:        true,
+			DeclarationProvider:       &DeclarationProvider{Value: true},
+			DefinitionProvider:        &DefinitionProvider{Value: true},
+			TypeDefinitionProvider:    &TypeDefinitionProvider{Value: true},
+			ImplementationProvider:    &ImplementationProvider{Value: true},
+			ReferencesProvider:        &ReferencesProvider{Value: true},
+			DocumentHighlightProvider: &DocumentHighlightProvider{Value: true},
+			DocumentSymbolProvider:    &DocumentSymbolProvider{Value: true},
+			WorkspaceSymbolProvider:   &WorkspaceSymbolProvider{Value: true},
+			CodeActionProvider:        &CodeActionProvider{Value: true},
 			CodeLensProvider: &CodeLensOptions{
 				ResolveProvider: true,
 			},
-			DocumentFormattingProvider:      true,
-			DocumentRangeFormattingProvider: true,
+			DocumentFormattingProvider:      &DocumentFormattingProvider{Value: true},
+			DocumentRangeFormattingProvider: &DocumentRangeFormattingProvider{Value: true},
 			DocumentOnTypeFormattingProvider: &DocumentOnTypeFormattingOptions{
 				FirstTriggerCharacter: ".",
 				MoreTriggerCharacter:  []string{"f"},
 			},
-			RenameProvider: true,
+			RenameProvider: &RenameProvider{Value: true},
 			DocumentLinkProvider: &DocumentLinkOptions{
 				ResolveProvider: true,
 			},
-			ColorProvider:          true,
-			FoldingRangeProvider:   true,
-			SelectionRangeProvider: true,
+			ColorProvider:          &ColorProvider{Value: true},
+			FoldingRangeProvider:   &FoldingRangeProvider{Value: true},
+			SelectionRangeProvider: &SelectionRangeProvider{Value: true},
 			ExecuteCommandProvider: &ExecuteCommandOptions{
 				Commands: []string{"test", "command"},
 			},
@@ -3897,10 +3956,10 @@ func TestInitializeResult(t *testing.T) {
 					},
 				},
 			},
-			LinkedEditingRangeProvider: true,
-			CallHierarchyProvider:      true,
+			LinkedEditingRangeProvider: &LinkedEditingRangeProvider{Value: true},
+			CallHierarchyProvider:      &CallHierarchyProvider{Value: true},
 			SemanticTokensProvider:     nil,
-			MonikerProvider:            true,
+			MonikerProvider:            &MonikerProvider{Value: true},
 			Experimental:               "Awesome Experimentals",
 		},
 		ServerInfo: &ServerInfo{
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"strconv"
+)
+
+// InlayHintParams is a parameter literal used in inlay hint requests.
+//
+// @since 3.17.0.
+type InlayHintParams struct {
+	WorkDoneProgressParams
+
+	// TextDocument is the text document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Range is the visible document range for which inlay hints should be computed.
+	Range Range `json:"range"`
+}
+
+// InlayHintKind is the inlay hint kinds.
+//
+// @since 3.17.0.
+type InlayHintKind float64
+
+// list of InlayHintKind.
+const (
+	// InlayHintKindType is an inlay hint that is for a type annotation.
+	InlayHintKindType InlayHintKind = 1
+
+	// InlayHintKindParameter is an inlay hint that is for a parameter.
+	InlayHintKindParameter InlayHintKind = 2
+)
+
+// String implements fmt.Stringer.
+func (k InlayHintKind) String() string {
+	switch k {
+	case InlayHintKindType:
+		return "Type"
+	case InlayHintKindParameter:
+		return "Parameter"
+	default:
+		return strconv.FormatFloat(float64(k), 'f', -10, 64)
+	}
+}
+
+// InlayHintLabelPart is an inlay hint label part allows for interactive and composite labels
+// of inlay hints.
+//
+// @since 3.17.0.
+type InlayHintLabelPart struct {
+	// Value is the value of this label part.
+	Value string `json:"value"`
+
+	// Tooltip is the tooltip text when you hover over this label part. Depending on
+	// the client capability "inlayHint.resolveSupport" clients might resolve
+	// this property late using the resolve request.
+	Tooltip interface{} `json:"tooltip,omitempty"` // string | *MarkupContent
+
+	// Location is an optional source code location that represents this
+	// label part.
+	//
+	// The editor will use this location for the hover and for code navigation
+	// features: This part will become a clickable link that resolves to the
+	// definition of the symbol at the given location (not necessarily the
+	// location itself), it shows the hover that shows at the given location,
+	// and it shows a context menu with further code navigation commands.
+	//
+	// Depending on the client capability "inlayHint.resolveSupport" clients
+	// might resolve this property late using the resolve request.
+	Location *Location `json:"location,omitempty"`
+
+	// Command is an optional command for this label part.
+	//
+	// Depending on the client capability "inlayHint.resolveSupport" clients
+	// might resolve this property late using the resolve request.
+	Command *Command `json:"command,omitempty"`
+}
+
+// InlayHint is the inlay hint information.
+//
+// @since 3.17.0.
+type InlayHint struct {
+	// Position is the position of this hint.
+	Position Position `json:"position"`
+
+	// Label is the label of this hint. A human readable string or an array of
+	// InlayHintLabelPart label parts.
+	//
+	// NOTE: Labels should not be empty.
+	Label interface{} `json:"label"` // string | []InlayHintLabelPart
+
+	// Kind is the kind of this hint. Can be omitted in which case the client
+	// should fall back to a reasonable default.
+	Kind InlayHintKind `json:"kind,omitempty"`
+
+	// TextEdits optional text edits that are performed when accepting this inlay hint.
+	//
+	// NOTE: Edits are expected to change the document so that the inlay
+	// hint (or its nearest variant) is now part of the document and the inlay
+	// hint itself is now obsolete.
+	TextEdits []TextEdit `json:"textEdits,omitempty"`
+
+	// Tooltip is the tooltip text when you hover over this item.
+	Tooltip interface{} `json:"tooltip,omitempty"` // string | *MarkupContent
+
+	// PaddingLeft render padding before the hint.
+	//
+	// NOTE: Padding should use the editor's background color, not the
+	// background color of the hint itself. That means padding can be used
+	// to visually align/separate an inlay hint.
+	PaddingLeft bool `json:"paddingLeft,omitempty"`
+
+	// PaddingRight render padding after the hint.
+	//
+	// NOTE: Padding should use the editor's background color, not the
+	// background color of the hint itself. That means padding can be used
+	// to visually align/separate an inlay hint.
+	PaddingRight bool `json:"paddingRight,omitempty"`
+
+	// Data is a data entry field that is preserved on an inlay hint between
+	// a "textDocument/inlayHint" and a "inlayHint/resolve" request.
+	Data interface{} `json:"data,omitempty"`
+}
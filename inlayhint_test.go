@@ -0,0 +1,343 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/segmentio/encoding/json"
+
+	"go.lsp.dev/uri"
+)
+
+func TestInlayHintParams(t *testing.T) {
+	const (
+		wantWorkDoneToken    = "156edea9-9d8d-422f-b7ee-81a84594afbb"
+		invalidWorkDoneToken = "dd134d84-c134-4d7a-a2a3-f8af3ef4a568"
+	)
+	const (
+		want        = `{"workDoneToken":"` + wantWorkDoneToken + `","textDocument":{"uri":"file:///path/to/basic.go"},"range":{"start":{"line":25,"character":1},"end":{"line":27,"character":3}}}`
+		wantNil     = `{"textDocument":{"uri":"file:///path/to/basic.go"},"range":{"start":{"line":25,"character":1},"end":{"line":27,"character":3}}}`
+		wantInvalid = `{"workDoneToken":"` + invalidWorkDoneToken + `","textDocument":{"uri":"file:///path/to/basic_gen.go"},"range":{"start":{"line":2,"character":1},"end":{"line":3,"character":2}}}`
+	)
+	wantType := InlayHintParams{
+		WorkDoneProgressParams: WorkDoneProgressParams{
+			WorkDoneToken: NewProgressToken(wantWorkDoneToken),
+		},
+		TextDocument: TextDocumentIdentifier{
+			URI: uri.File("/path/to/basic.go"),
+		},
+		Range: Range{
+			Start: Position{
+				Line:      25,
+				Character: 1,
+			},
+			End: Position{
+				Line:      27,
+				Character: 3,
+			},
+		},
+	}
+	wantTypeNil := InlayHintParams{
+		TextDocument: TextDocumentIdentifier{
+			URI: uri.File("/path/to/basic.go"),
+		},
+		Range: Range{
+			Start: Position{
+				Line:      25,
+				Character: 1,
+			},
+			End: Position{
+				Line:      27,
+				Character: 3,
+			},
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          InlayHintParams
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Valid",
+				field:          wantType,
+				want:           want,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Nil",
+				field:          wantTypeNil,
+				want:           wantNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantType,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             InlayHintParams
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Valid",
+				field:            want,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Nil",
+				field:            wantNil,
+				want:             wantTypeNil,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got InlayHintParams
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreTypes(WorkDoneProgressParams{})); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+
+				if workDoneToken := got.WorkDoneToken; workDoneToken != nil {
+					if diff := cmp.Diff(fmt.Sprint(workDoneToken), wantWorkDoneToken); (diff != "") != tt.wantErr {
+						t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+					}
+				}
+			})
+		}
+	})
+}
+
+func TestInlayHintKind_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		k    InlayHintKind
+		want string
+	}{
+		{
+			name: "Type",
+			k:    InlayHintKindType,
+			want: "Type",
+		},
+		{
+			name: "Parameter",
+			k:    InlayHintKindParameter,
+			want: "Parameter",
+		},
+		{
+			name: "Unknown",
+			k:    InlayHintKind(0),
+			want: "0",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.k.String(); got != tt.want {
+				t.Errorf("InlayHintKind.String() = %v, want %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestInlayHint(t *testing.T) {
+	const (
+		want        = `{"position":{"line":25,"character":1},"label":": string","kind":1,"textEdits":[{"range":{"start":{"line":25,"character":1},"end":{"line":25,"character":1}},"newText":": string"}],"tooltip":"testTooltip","paddingLeft":true,"data":"testData"}`
+		wantNil     = `{"position":{"line":25,"character":1},"label":": string"}`
+		wantInvalid = `{"position":{"line":2,"character":1},"label":": int","kind":2,"textEdits":[{"range":{"start":{"line":2,"character":1},"end":{"line":2,"character":1}},"newText":": int"}],"tooltip":"invalidTooltip","paddingRight":true,"data":"invalidData"}`
+	)
+	wantType := InlayHint{
+		Position: Position{
+			Line:      25,
+			Character: 1,
+		},
+		Label: ": string",
+		Kind:  InlayHintKindType,
+		TextEdits: []TextEdit{
+			{
+				Range: Range{
+					Start: Position{
+						Line:      25,
+						Character: 1,
+					},
+					End: Position{
+						Line:      25,
+						Character: 1,
+					},
+				},
+				NewText: ": string",
+			},
+		},
+		Tooltip:     "testTooltip",
+		PaddingLeft: true,
+		Data:        "testData",
+	}
+	wantTypeNil := InlayHint{
+		Position: Position{
+			Line:      25,
+			Character: 1,
+		},
+		Label: ": string",
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          InlayHint
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Valid",
+				field:          wantType,
+				want:           want,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Nil",
+				field:          wantTypeNil,
+				want:           wantNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantType,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             InlayHint
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Valid",
+				field:            want,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Nil",
+				field:            wantNil,
+				want:             wantTypeNil,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got InlayHint
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+}
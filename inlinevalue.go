@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"errors"
+
+	"github.com/segmentio/encoding/json"
+)
+
+// InlineValueParams is a parameter literal used in inline value requests.
+//
+// @since 3.17.0.
+type InlineValueParams struct {
+	WorkDoneProgressParams
+
+	// TextDocument is the text document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Range is the document range for which inline values should be computed.
+	Range Range `json:"range"`
+
+	// Context is the additional information about the context in which inline values were
+	// requested.
+	Context InlineValueContext `json:"context"`
+}
+
+// InlineValueContext is the additional information about the context in which inline values were requested.
+//
+// @since 3.17.0.
+type InlineValueContext struct {
+	// FrameID is the stack frame (as a DAP Id) where the execution has stopped.
+	FrameID int32 `json:"frameId"`
+
+	// StoppedLocation is the document range where execution has stopped.
+	// Typically the end position of the range denotes the line where the
+	// inline values are shown.
+	StoppedLocation Range `json:"stoppedLocation"`
+}
+
+// InlineValueText provide inline value as text.
+//
+// @since 3.17.0.
+type InlineValueText struct {
+	// Range is the document range for which the inline value applies.
+	Range Range `json:"range"`
+
+	// Text is the text of the inline value.
+	Text string `json:"text"`
+}
+
+// InlineValueVariableLookup provide inline value through a variable lookup.
+//
+// If only a range is specified, the variable name will be extracted from
+// the underlying document.
+//
+// An optional variable name can be used to override the extracted name.
+//
+// @since 3.17.0.
+type InlineValueVariableLookup struct {
+	// Range is the document range for which the inline value applies.
+	// The range is used to extract the variable name from the underlying
+	// document.
+	Range Range `json:"range"`
+
+	// VariableName if specified the name of the variable to look up.
+	VariableName string `json:"variableName,omitempty"`
+
+	// CaseSensitiveLookup how to perform the lookup.
+	CaseSensitiveLookup bool `json:"caseSensitiveLookup"`
+}
+
+// InlineValueEvaluatableExpression provide an inline value through an expression evaluation.
+//
+// If only a range is specified, the expression will be extracted from the
+// underlying document.
+//
+// An optional expression can be used to override the extracted expression.
+//
+// @since 3.17.0.
+type InlineValueEvaluatableExpression struct {
+	// Range is the document range for which the inline value applies.
+	// The range is used to extract the evaluatable expression from the
+	// underlying document.
+	Range Range `json:"range"`
+
+	// Expression if specified the expression overrides the extracted expression.
+	Expression string `json:"expression,omitempty"`
+}
+
+// InlineValue is inline value information can be provided by different means:
+//
+//   - directly as a text value (class InlineValueText).
+//   - as a name to use for a variable lookup (class InlineValueVariableLookup)
+//   - as an evaluatable expression (class InlineValueEvaluatableExpression)
+//
+// Exactly one of Text, VariableLookup or EvaluatableExpression is set.
+// The JSON representation carries no discriminator, so the variant is
+// detected by the presence of the "text" or "caseSensitiveLookup" property.
+//
+// @since 3.17.0.
+type InlineValue struct {
+	Text                  *InlineValueText
+	VariableLookup        *InlineValueVariableLookup
+	EvaluatableExpression *InlineValueEvaluatableExpression
+}
+
+// compile time check whether the InlineValue implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*InlineValue)(nil)
+	_ json.Unmarshaler = (*InlineValue)(nil)
+)
+
+// errEmptyInlineValue is returned when marshaling an InlineValue which has no variant set.
+var errEmptyInlineValue = errors.New("inline value has neither text, variable lookup nor evaluatable expression")
+
+// MarshalJSON implements json.Marshaler.
+func (v InlineValue) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.Text != nil:
+		return json.Marshal(v.Text)
+	case v.VariableLookup != nil:
+		return json.Marshal(v.VariableLookup)
+	case v.EvaluatableExpression != nil:
+		return json.Marshal(v.EvaluatableExpression)
+	default:
+		return nil, errEmptyInlineValue
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *InlineValue) UnmarshalJSON(data []byte) error {
+	*v = InlineValue{}
+
+	var probe struct {
+		Text                *string `json:"text"`
+		CaseSensitiveLookup *bool   `json:"caseSensitiveLookup"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+
+	switch {
+	case probe.Text != nil:
+		v.Text = &InlineValueText{}
+		return json.Unmarshal(data, v.Text)
+	case probe.CaseSensitiveLookup != nil:
+		v.VariableLookup = &InlineValueVariableLookup{}
+		return json.Unmarshal(data, v.VariableLookup)
+	default:
+		v.EvaluatableExpression = &InlineValueEvaluatableExpression{}
+		return json.Unmarshal(data, v.EvaluatableExpression)
+	}
+}
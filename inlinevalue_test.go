@@ -0,0 +1,374 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/segmentio/encoding/json"
+
+	"go.lsp.dev/uri"
+)
+
+func TestInlineValueParams(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantWorkDoneToken    = "156edea9-9d8d-422f-b7ee-81a84594afbb"
+		invalidWorkDoneToken = "dd134d84-c134-4d7a-a2a3-f8af3ef4a568"
+	)
+	const (
+		want        = `{"workDoneToken":"` + wantWorkDoneToken + `","textDocument":{"uri":"file:///path/to/basic.go"},"range":{"start":{"line":25,"character":1},"end":{"line":27,"character":3}},"context":{"frameId":5,"stoppedLocation":{"start":{"line":26,"character":0},"end":{"line":26,"character":10}}}}`
+		wantNil     = `{"textDocument":{"uri":"file:///path/to/basic.go"},"range":{"start":{"line":25,"character":1},"end":{"line":27,"character":3}},"context":{"frameId":0,"stoppedLocation":{"start":{"line":0,"character":0},"end":{"line":0,"character":0}}}}`
+		wantInvalid = `{"workDoneToken":"` + invalidWorkDoneToken + `","textDocument":{"uri":"file:///path/to/basic_gen.go"},"range":{"start":{"line":2,"character":1},"end":{"line":3,"character":2}},"context":{"frameId":1,"stoppedLocation":{"start":{"line":2,"character":0},"end":{"line":2,"character":1}}}}`
+	)
+	wantType := InlineValueParams{
+		WorkDoneProgressParams: WorkDoneProgressParams{
+			WorkDoneToken: NewProgressToken(wantWorkDoneToken),
+		},
+		TextDocument: TextDocumentIdentifier{
+			URI: uri.File("/path/to/basic.go"),
+		},
+		Range: Range{
+			Start: Position{
+				Line:      25,
+				Character: 1,
+			},
+			End: Position{
+				Line:      27,
+				Character: 3,
+			},
+		},
+		Context: InlineValueContext{
+			FrameID: 5,
+			StoppedLocation: Range{
+				Start: Position{
+					Line:      26,
+					Character: 0,
+				},
+				End: Position{
+					Line:      26,
+					Character: 10,
+				},
+			},
+		},
+	}
+	wantTypeNil := InlineValueParams{
+		TextDocument: TextDocumentIdentifier{
+			URI: uri.File("/path/to/basic.go"),
+		},
+		Range: Range{
+			Start: Position{
+				Line:      25,
+				Character: 1,
+			},
+			End: Position{
+				Line:      27,
+				Character: 3,
+			},
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          InlineValueParams
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Valid",
+				field:          wantType,
+				want:           want,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Nil",
+				field:          wantTypeNil,
+				want:           wantNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantType,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             InlineValueParams
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Valid",
+				field:            want,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Nil",
+				field:            wantNil,
+				want:             wantTypeNil,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got InlineValueParams
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreTypes(WorkDoneProgressParams{})); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+
+				if workDoneToken := got.WorkDoneToken; workDoneToken != nil {
+					if diff := cmp.Diff(fmt.Sprint(workDoneToken), wantWorkDoneToken); (diff != "") != tt.wantErr {
+						t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+					}
+				}
+			})
+		}
+	})
+}
+
+func TestInlineValue(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantText                  = `{"range":{"start":{"line":25,"character":1},"end":{"line":25,"character":4}},"text":"foo = 1"}`
+		wantVariableLookup        = `{"range":{"start":{"line":25,"character":1},"end":{"line":25,"character":4}},"variableName":"foo","caseSensitiveLookup":true}`
+		wantVariableLookupNil     = `{"range":{"start":{"line":25,"character":1},"end":{"line":25,"character":4}},"caseSensitiveLookup":false}`
+		wantEvaluatableExpression = `{"range":{"start":{"line":25,"character":1},"end":{"line":25,"character":4}},"expression":"foo.bar"}`
+		wantEvaluatableNil        = `{"range":{"start":{"line":25,"character":1},"end":{"line":25,"character":4}}}`
+	)
+	testRange := Range{
+		Start: Position{
+			Line:      25,
+			Character: 1,
+		},
+		End: Position{
+			Line:      25,
+			Character: 4,
+		},
+	}
+	wantTypeText := InlineValue{
+		Text: &InlineValueText{
+			Range: testRange,
+			Text:  "foo = 1",
+		},
+	}
+	wantTypeVariableLookup := InlineValue{
+		VariableLookup: &InlineValueVariableLookup{
+			Range:               testRange,
+			VariableName:        "foo",
+			CaseSensitiveLookup: true,
+		},
+	}
+	wantTypeVariableLookupNil := InlineValue{
+		VariableLookup: &InlineValueVariableLookup{
+			Range: testRange,
+		},
+	}
+	wantTypeEvaluatableExpression := InlineValue{
+		EvaluatableExpression: &InlineValueEvaluatableExpression{
+			Range:      testRange,
+			Expression: "foo.bar",
+		},
+	}
+	wantTypeEvaluatableNil := InlineValue{
+		EvaluatableExpression: &InlineValueEvaluatableExpression{
+			Range: testRange,
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          InlineValue
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Text",
+				field:          wantTypeText,
+				want:           wantText,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "VariableLookup",
+				field:          wantTypeVariableLookup,
+				want:           wantVariableLookup,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "VariableLookupNil",
+				field:          wantTypeVariableLookupNil,
+				want:           wantVariableLookupNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "EvaluatableExpression",
+				field:          wantTypeEvaluatableExpression,
+				want:           wantEvaluatableExpression,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Empty",
+				field:          InlineValue{},
+				want:           "",
+				wantMarshalErr: true,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantTypeText,
+				want:           wantEvaluatableExpression,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             InlineValue
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Text",
+				field:            wantText,
+				want:             wantTypeText,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "VariableLookup",
+				field:            wantVariableLookup,
+				want:             wantTypeVariableLookup,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "VariableLookupNil",
+				field:            wantVariableLookupNil,
+				want:             wantTypeVariableLookupNil,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "EvaluatableExpression",
+				field:            wantEvaluatableExpression,
+				want:             wantTypeEvaluatableExpression,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "EvaluatableExpressionNil",
+				field:            wantEvaluatableNil,
+				want:             wantTypeEvaluatableNil,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantVariableLookup,
+				want:             wantTypeText,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got InlineValue
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+}
@@ -4,7 +4,12 @@
 package protocol
 
 import (
+	"bytes"
+	"errors"
 	"strconv"
+	"strings"
+
+	"github.com/segmentio/encoding/json"
 )
 
 // CompletionParams params of Completion request.
@@ -65,10 +70,211 @@ type CompletionList struct {
 	// this list.
 	IsIncomplete bool `json:"isIncomplete"`
 
+	// ItemDefaults in many cases the items of an actual completion result share the same
+	// value for properties like "commitCharacters" or the range of a text
+	// edit. A completion list can therefore define item defaults which will
+	// be used if a completion item itself doesn't specify the value.
+	//
+	// If a completion list specifies a default value and a completion item
+	// also specifies a corresponding value the one from the item is used.
+	//
+	// Servers are only allowed to return default values if the client
+	// signals support for this via the "completionList.itemDefaults"
+	// capability.
+	//
+	// @since 3.17.0.
+	ItemDefaults *CompletionListItemDefaults `json:"itemDefaults,omitempty"`
+
 	// Items is the completion items.
 	Items []CompletionItem `json:"items"`
 }
 
+// list of CompletionListItemDefaults property names.
+//
+// The names are the values a client announces in the "completionList.itemDefaults" capability.
+const (
+	// CompletionListItemDefaultCommitCharacters is the "commitCharacters" item default.
+	CompletionListItemDefaultCommitCharacters = "commitCharacters"
+
+	// CompletionListItemDefaultEditRange is the "editRange" item default.
+	CompletionListItemDefaultEditRange = "editRange"
+
+	// CompletionListItemDefaultInsertTextFormat is the "insertTextFormat" item default.
+	CompletionListItemDefaultInsertTextFormat = "insertTextFormat"
+
+	// CompletionListItemDefaultInsertTextMode is the "insertTextMode" item default.
+	CompletionListItemDefaultInsertTextMode = "insertTextMode"
+
+	// CompletionListItemDefaultData is the "data" item default.
+	CompletionListItemDefaultData = "data"
+)
+
+// CompletionListItemDefaults default values of the CompletionList items.
+//
+// @since 3.17.0.
+type CompletionListItemDefaults struct {
+	// CommitCharacters a default commit character set.
+	CommitCharacters []string `json:"commitCharacters,omitempty"`
+
+	// EditRange a default edit range.
+	EditRange *CompletionListItemDefaultsEditRange `json:"editRange,omitempty"`
+
+	// InsertTextFormat a default insert text format.
+	InsertTextFormat InsertTextFormat `json:"insertTextFormat,omitempty"`
+
+	// InsertTextMode a default insert text mode.
+	InsertTextMode InsertTextMode `json:"insertTextMode,omitempty"`
+
+	// Data a default data value.
+	Data interface{} `json:"data,omitempty"`
+}
+
+// CompletionListItemDefaultsEditRange is the default edit range of the CompletionList items.
+//
+// Exactly one of Range or InsertReplace is set.
+//
+// @since 3.17.0.
+type CompletionListItemDefaultsEditRange struct {
+	Range         *Range
+	InsertReplace *InsertReplaceRange
+}
+
+// InsertReplaceRange is the insert and replace ranges of a CompletionListItemDefaultsEditRange.
+//
+// @since 3.17.0.
+type InsertReplaceRange struct {
+	// Insert is the range if the insert is requested.
+	Insert Range `json:"insert"`
+
+	// Replace is the range if the replace is requested.
+	Replace Range `json:"replace"`
+}
+
+// compile time check whether the CompletionListItemDefaultsEditRange implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*CompletionListItemDefaultsEditRange)(nil)
+	_ json.Unmarshaler = (*CompletionListItemDefaultsEditRange)(nil)
+)
+
+var errEmptyCompletionListItemDefaultsEditRange = errors.New("empty CompletionListItemDefaultsEditRange")
+
+// MarshalJSON implements json.Marshaler.
+func (r CompletionListItemDefaultsEditRange) MarshalJSON() ([]byte, error) {
+	switch {
+	case r.Range != nil:
+		return json.Marshal(r.Range)
+	case r.InsertReplace != nil:
+		return json.Marshal(r.InsertReplace)
+	default:
+		return nil, errEmptyCompletionListItemDefaultsEditRange
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *CompletionListItemDefaultsEditRange) UnmarshalJSON(data []byte) error {
+	*r = CompletionListItemDefaultsEditRange{}
+
+	var probe struct {
+		Insert json.RawMessage `json:"insert"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+
+	if probe.Insert != nil {
+		return json.Unmarshal(data, &r.InsertReplace)
+	}
+
+	return json.Unmarshal(data, &r.Range)
+}
+
+// ExpandItemDefaults copies the ItemDefaults that the client does not support into each item
+// and removes them from the list.
+//
+// supported is the "completionList.itemDefaults" client capability. Values set on an item take
+// precedence over the defaults. An edit range default becomes the item's TextEdit, using
+// TextEditText or, if empty, Label as the new text.
+//
+// @since 3.17.0.
+func (l *CompletionList) ExpandItemDefaults(supported []string) {
+	defaults := l.ItemDefaults
+	if defaults == nil {
+		return
+	}
+
+	isSupported := func(name string) bool {
+		for _, s := range supported {
+			if s == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	expand := CompletionListItemDefaults{}
+	if !isSupported(CompletionListItemDefaultCommitCharacters) {
+		expand.CommitCharacters, defaults.CommitCharacters = defaults.CommitCharacters, nil
+	}
+	if !isSupported(CompletionListItemDefaultEditRange) {
+		expand.EditRange, defaults.EditRange = defaults.EditRange, nil
+	}
+	if !isSupported(CompletionListItemDefaultInsertTextFormat) {
+		expand.InsertTextFormat, defaults.InsertTextFormat = defaults.InsertTextFormat, 0
+	}
+	if !isSupported(CompletionListItemDefaultInsertTextMode) {
+		expand.InsertTextMode, defaults.InsertTextMode = defaults.InsertTextMode, 0
+	}
+	if !isSupported(CompletionListItemDefaultData) {
+		expand.Data, defaults.Data = defaults.Data, nil
+	}
+
+	for i := range l.Items {
+		item := &l.Items[i]
+		if item.CommitCharacters == nil && expand.CommitCharacters != nil {
+			item.CommitCharacters = append([]string(nil), expand.CommitCharacters...)
+		}
+		if item.TextEdit == nil && expand.EditRange != nil {
+			newText := item.TextEditText
+			if newText == "" {
+				newText = item.Label
+			}
+			switch {
+			case expand.EditRange.Range != nil:
+				item.TextEdit = &CompletionItemTextEdit{
+					TextEdit: &TextEdit{
+						Range:   *expand.EditRange.Range,
+						NewText: newText,
+					},
+				}
+			case expand.EditRange.InsertReplace != nil:
+				item.TextEdit = &CompletionItemTextEdit{
+					InsertReplaceEdit: &InsertReplaceEdit{
+						NewText: newText,
+						Insert:  expand.EditRange.InsertReplace.Insert,
+						Replace: expand.EditRange.InsertReplace.Replace,
+					},
+				}
+			}
+			if item.TextEdit != nil {
+				item.TextEditText = ""
+			}
+		}
+		if item.InsertTextFormat == 0 {
+			item.InsertTextFormat = expand.InsertTextFormat
+		}
+		if item.InsertTextMode == 0 {
+			item.InsertTextMode = expand.InsertTextMode
+		}
+		if item.Data == nil {
+			item.Data = expand.Data
+		}
+	}
+
+	if defaults.CommitCharacters == nil && defaults.EditRange == nil && defaults.InsertTextFormat == 0 && defaults.InsertTextMode == 0 && defaults.Data == nil {
+		l.ItemDefaults = nil
+	}
+}
+
 // InsertTextFormat defines whether the insert text in a completion item should be interpreted as
 // plain text or a snippet.
 type InsertTextFormat float64
@@ -186,7 +392,7 @@ type CompletionItem struct {
 	Detail string `json:"detail,omitempty"`
 
 	// Documentation a human-readable string that represents a doc-comment.
-	Documentation interface{} `json:"documentation,omitempty"`
+	Documentation *Documentation `json:"documentation,omitempty"` // string | MarkupContent
 
 	// FilterText a string that should be used when filtering a set of
 	// completion items. When `falsy` the label is used.
@@ -223,6 +429,11 @@ type CompletionItem struct {
 	// this completion.
 	Label string `json:"label"`
 
+	// LabelDetails additional details for the label.
+	//
+	// @since 3.17.0.
+	LabelDetails *CompletionItemLabelDetails `json:"labelDetails,omitempty"`
+
 	// Preselect select this item when showing.
 	//
 	// *Note* that only one completion item can be selected and that the
@@ -257,7 +468,113 @@ type CompletionItem struct {
 	// contained and starting at the same position.
 	//
 	// @since 3.16.0 additional type "InsertReplaceEdit".
-	TextEdit *TextEdit `json:"textEdit,omitempty"` // *TextEdit | *InsertReplaceEdit
+	TextEdit *CompletionItemTextEdit `json:"textEdit,omitempty"` // *TextEdit | *InsertReplaceEdit
+
+	// TextEditText is the edit text used if the completion item is part of a CompletionList and
+	// CompletionList defines an item default for the text edit range.
+	//
+	// Clients will only honor this property if they opt into completion list
+	// item defaults using the capability "completionList.itemDefaults".
+	//
+	// If not provided and a list's default range is provided the label
+	// property is used as a text.
+	//
+	// @since 3.17.0.
+	TextEditText string `json:"textEditText,omitempty"`
+}
+
+// CollapseTextEdit replaces an InsertReplaceEdit TextEdit with a plain TextEdit using the insert
+// range if the client doesn't signal "insertReplaceSupport".
+//
+// @since 3.16.0.
+func (i *CompletionItem) CollapseTextEdit(caps *CompletionTextDocumentClientCapabilitiesItem) {
+	if i.TextEdit == nil || i.TextEdit.InsertReplaceEdit == nil {
+		return
+	}
+	if caps != nil && caps.InsertReplaceSupport {
+		return
+	}
+
+	edit := i.TextEdit.Collapse()
+	i.TextEdit = &CompletionItemTextEdit{TextEdit: &edit}
+}
+
+// CompletionItemTextEdit is the TextEdit of a CompletionItem.
+//
+// Exactly one of TextEdit or InsertReplaceEdit is set. The variant is selected by the
+// "insert" property.
+//
+// @since 3.16.0.
+type CompletionItemTextEdit struct {
+	TextEdit          *TextEdit
+	InsertReplaceEdit *InsertReplaceEdit
+}
+
+// compile time check whether the CompletionItemTextEdit implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*CompletionItemTextEdit)(nil)
+	_ json.Unmarshaler = (*CompletionItemTextEdit)(nil)
+)
+
+var errEmptyCompletionItemTextEdit = errors.New("empty CompletionItemTextEdit")
+
+// Collapse returns the edit as a plain TextEdit. An InsertReplaceEdit collapses to its insert range.
+func (e CompletionItemTextEdit) Collapse() TextEdit {
+	switch {
+	case e.TextEdit != nil:
+		return *e.TextEdit
+	case e.InsertReplaceEdit != nil:
+		return TextEdit{
+			Range:   e.InsertReplaceEdit.Insert,
+			NewText: e.InsertReplaceEdit.NewText,
+		}
+	default:
+		return TextEdit{}
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e CompletionItemTextEdit) MarshalJSON() ([]byte, error) {
+	switch {
+	case e.TextEdit != nil:
+		return json.Marshal(e.TextEdit)
+	case e.InsertReplaceEdit != nil:
+		return json.Marshal(e.InsertReplaceEdit)
+	default:
+		return nil, errEmptyCompletionItemTextEdit
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *CompletionItemTextEdit) UnmarshalJSON(data []byte) error {
+	*e = CompletionItemTextEdit{}
+
+	var probe struct {
+		Insert json.RawMessage `json:"insert"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+
+	if probe.Insert != nil {
+		return json.Unmarshal(data, &e.InsertReplaceEdit)
+	}
+
+	return json.Unmarshal(data, &e.TextEdit)
+}
+
+// CompletionItemLabelDetails additional details for a completion item label.
+//
+// @since 3.17.0.
+type CompletionItemLabelDetails struct {
+	// Detail an optional string which is rendered less prominently directly after
+	// label, without any spacing. Should be used for function signatures or type
+	// annotations.
+	Detail string `json:"detail,omitempty"`
+
+	// Description an optional string which is rendered less prominently after
+	// detail. Should be used for fully qualified names or file path.
+	Description string `json:"description,omitempty"`
 }
 
 // CompletionItemKind is the completion item kind values the client supports. When this
@@ -434,13 +751,267 @@ type HoverParams struct {
 // Hover is the result of a hover request.
 type Hover struct {
 	// Contents is the hover's content
-	Contents MarkupContent `json:"contents"`
+	Contents HoverContents `json:"contents"` // MarkupContent | MarkedString | []MarkedString
 
 	// Range an optional range is a range inside a text document
 	// that is used to visualize a hover, e.g. by changing the background color.
 	Range *Range `json:"range,omitempty"`
 }
 
+// NormalizeContents replaces the Contents with a MarkupContent in the format preferred by the client.
+func (h *Hover) NormalizeContents(caps *HoverTextDocumentClientCapabilities) {
+	var contentFormat []MarkupKind
+	if caps != nil {
+		contentFormat = caps.ContentFormat
+	}
+
+	content := h.Contents.Normalize(contentFormat)
+	h.Contents = HoverContents{MarkupContent: &content}
+}
+
+// HoverContents is the contents of a Hover.
+//
+// Exactly one of MarkupContent, MarkedString or MarkedStrings is set.
+// MarkedString and MarkedStrings are deprecated in favor of MarkupContent.
+type HoverContents struct {
+	MarkupContent *MarkupContent
+	MarkedString  *MarkedString
+	MarkedStrings []MarkedString
+}
+
+// compile time check whether the HoverContents implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*HoverContents)(nil)
+	_ json.Unmarshaler = (*HoverContents)(nil)
+)
+
+var errEmptyHoverContents = errors.New("hover contents has neither markup content nor marked strings")
+
+// Normalize converts the contents into a MarkupContent the client can render.
+//
+// A MarkupContent whose kind is in contentFormat is returned as is. Anything else is converted to the first kind of
+// contentFormat that is supported, defaulting to PlainText: plain text is escaped when converted to Markdown, and
+// Markdown converted to plain text keeps its text and code but loses the fences of its code blocks and its escapes.
+//
+// MarkedStrings are joined by an empty line, and language code blocks are fenced in Markdown.
+func (c HoverContents) Normalize(contentFormat []MarkupKind) MarkupContent {
+	kind := PlainText
+	for _, format := range contentFormat {
+		if format == PlainText || format == Markdown {
+			kind = format
+			break
+		}
+	}
+
+	switch {
+	case c.MarkupContent != nil:
+		content := *c.MarkupContent
+		for _, format := range contentFormat {
+			if format == content.Kind {
+				return content
+			}
+		}
+		if content.Kind == kind {
+			return content
+		}
+		if kind == Markdown {
+			return MarkupContent{Kind: Markdown, Value: escapeMarkdown(content.Value)}
+		}
+		return MarkupContent{Kind: PlainText, Value: markdownText(content.Value)}
+	case c.MarkedString != nil:
+		return MarkupContent{Kind: kind, Value: c.MarkedString.format(kind)}
+	default:
+		values := make([]string, 0, len(c.MarkedStrings))
+		for _, ms := range c.MarkedStrings {
+			if value := ms.format(kind); value != "" {
+				values = append(values, value)
+			}
+		}
+		return MarkupContent{Kind: kind, Value: strings.Join(values, "\n\n")}
+	}
+}
+
+// markdownEscapes are the characters that have a meaning in Markdown.
+const markdownEscapes = "\\`*_{}[]()<>#+-.!|~"
+
+// escapeMarkdown escapes the characters of text that have a meaning in Markdown.
+func escapeMarkdown(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for _, r := range text {
+		if strings.ContainsRune(markdownEscapes, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// markdownText returns markdown as plain text.
+//
+// The fence lines of code blocks are dropped and the backslash escapes outside of them are removed; the rest of the
+// text, code included, is kept as is.
+func markdownText(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+	text := make([]string, 0, len(lines))
+	fence := ""
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence == "" && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")):
+			fence = trimmed[:3]
+		case fence != "" && strings.HasPrefix(trimmed, fence):
+			fence = ""
+		case fence != "":
+			text = append(text, line)
+		default:
+			text = append(text, unescapeMarkdown(line))
+		}
+	}
+
+	return strings.Join(text, "\n")
+}
+
+// unescapeMarkdown removes the backslashes escaping the characters of text that have a meaning in Markdown.
+func unescapeMarkdown(text string) string {
+	if !strings.Contains(text, "\\") {
+		return text
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) && strings.IndexByte(markdownEscapes, text[i+1]) >= 0 {
+			i++
+		}
+		b.WriteByte(text[i])
+	}
+
+	return b.String()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c HoverContents) MarshalJSON() ([]byte, error) {
+	switch {
+	case c.MarkupContent != nil:
+		return json.Marshal(c.MarkupContent)
+	case c.MarkedString != nil:
+		return json.Marshal(c.MarkedString)
+	case c.MarkedStrings != nil:
+		return json.Marshal(c.MarkedStrings)
+	default:
+		return nil, errEmptyHoverContents
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *HoverContents) UnmarshalJSON(data []byte) error {
+	*c = HoverContents{}
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return errEmptyHoverContents
+	}
+
+	switch data[0] {
+	case '[':
+		return json.Unmarshal(data, &c.MarkedStrings)
+	case '{':
+		var probe struct {
+			Kind json.RawMessage `json:"kind"`
+		}
+		if err := json.Unmarshal(data, &probe); err != nil {
+			return err
+		}
+		if probe.Kind != nil {
+			return json.Unmarshal(data, &c.MarkupContent)
+		}
+	}
+
+	return json.Unmarshal(data, &c.MarkedString)
+}
+
+// MarkedString can be used to render human readable text. It is either a Markdown string
+// or a code block that provides a language and a code snippet.
+//
+// Exactly one of Markdown or Code is set.
+//
+// Deprecated: use MarkupContent instead.
+type MarkedString struct {
+	Markdown *string
+	Code     *MarkedStringCode
+}
+
+// MarkedStringCode is the code block form of a MarkedString.
+//
+// Deprecated: use MarkupContent instead.
+type MarkedStringCode struct {
+	// Language is the language identifier of the code.
+	Language string `json:"language"`
+
+	// Value is the code.
+	Value string `json:"value"`
+}
+
+// compile time check whether the MarkedString implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*MarkedString)(nil)
+	_ json.Unmarshaler = (*MarkedString)(nil)
+)
+
+var errEmptyMarkedString = errors.New("marked string has neither markdown nor code")
+
+// NewMarkedString returns the MarkedString of a Markdown string.
+func NewMarkedString(markdown string) MarkedString {
+	return MarkedString{Markdown: &markdown}
+}
+
+// NewMarkedStringCode returns the MarkedString of a code block.
+func NewMarkedStringCode(language, value string) MarkedString {
+	return MarkedString{Code: &MarkedStringCode{Language: language, Value: value}}
+}
+
+// format returns the value of the MarkedString rendered in kind.
+func (ms MarkedString) format(kind MarkupKind) string {
+	switch {
+	case ms.Markdown != nil && kind == Markdown:
+		return *ms.Markdown
+	case ms.Markdown != nil:
+		return markdownText(*ms.Markdown)
+	case ms.Code != nil && kind == Markdown:
+		return "```" + ms.Code.Language + "\n" + ms.Code.Value + "\n```"
+	case ms.Code != nil:
+		return ms.Code.Value
+	default:
+		return ""
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (ms MarkedString) MarshalJSON() ([]byte, error) {
+	switch {
+	case ms.Markdown != nil:
+		return json.Marshal(ms.Markdown)
+	case ms.Code != nil:
+		return json.Marshal(ms.Code)
+	default:
+		return nil, errEmptyMarkedString
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (ms *MarkedString) UnmarshalJSON(data []byte) error {
+	*ms = MarkedString{}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		return json.Unmarshal(data, &ms.Code)
+	}
+
+	return json.Unmarshal(data, &ms.Markdown)
+}
+
 // SignatureHelpParams params of SignatureHelp request.
 //
 // @since 3.15.0.
@@ -556,7 +1127,7 @@ type SignatureInformation struct {
 	// in the UI but can be omitted.
 	//
 	// @since 3.16.0.
-	Documentation interface{} `json:"documentation,omitempty"` // string | *MarkupContent
+	Documentation *Documentation `json:"documentation,omitempty"` // string | MarkupContent
 
 	// Parameters is the parameters of this signature.
 	//
@@ -585,7 +1156,7 @@ type ParameterInformation struct {
 
 	// Documentation is the human-readable doc-comment of this parameter. Will be shown
 	// in the UI but can be omitted.
-	Documentation interface{} `json:"documentation,omitempty"` // string | MarkupContent
+	Documentation *Documentation `json:"documentation,omitempty"` // string | MarkupContent
 }
 
 // SignatureHelpRegistrationOptions SignatureHelp Registration options.
@@ -881,6 +1452,170 @@ type SymbolInformation struct {
 	ContainerName string `json:"containerName,omitempty"`
 }
 
+// DocumentSymbolResult is the result of a "textDocument/documentSymbol" request.
+//
+// The result is either a hierarchical DocumentSymbol array or a flat SymbolInformation array,
+// depending on the "hierarchicalDocumentSymbolSupport" client capability.
+// DocumentSymbols takes precedence when marshaling.
+type DocumentSymbolResult struct {
+	DocumentSymbols   []DocumentSymbol
+	SymbolInformation []SymbolInformation
+}
+
+// compile time check whether the DocumentSymbolResult implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*DocumentSymbolResult)(nil)
+	_ json.Unmarshaler = (*DocumentSymbolResult)(nil)
+)
+
+// NewDocumentSymbolResult returns the DocumentSymbolResult of the symbols of uri for a client with or
+// without hierarchical document symbol support.
+func NewDocumentSymbolResult(uri DocumentURI, symbols []DocumentSymbol, hierarchicalSupport bool) *DocumentSymbolResult {
+	if hierarchicalSupport {
+		return &DocumentSymbolResult{DocumentSymbols: symbols}
+	}
+
+	return &DocumentSymbolResult{SymbolInformation: DocumentSymbolsToSymbolInformation(uri, symbols)}
+}
+
+// Hierarchy returns the result as DocumentSymbols, converting SymbolInformation if needed.
+func (r *DocumentSymbolResult) Hierarchy() []DocumentSymbol {
+	if r.DocumentSymbols != nil || r.SymbolInformation == nil {
+		return r.DocumentSymbols
+	}
+
+	return SymbolInformationToDocumentSymbols(r.SymbolInformation)
+}
+
+// Flat returns the result as SymbolInformation, converting DocumentSymbols of uri if needed.
+func (r *DocumentSymbolResult) Flat(uri DocumentURI) []SymbolInformation {
+	if r.DocumentSymbols == nil {
+		return r.SymbolInformation
+	}
+
+	return DocumentSymbolsToSymbolInformation(uri, r.DocumentSymbols)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r DocumentSymbolResult) MarshalJSON() ([]byte, error) {
+	if r.DocumentSymbols != nil {
+		return json.Marshal(r.DocumentSymbols)
+	}
+
+	return json.Marshal(r.SymbolInformation)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// An array is decoded as DocumentSymbols if its first element has no "location", and as
+// SymbolInformation otherwise.
+func (r *DocumentSymbolResult) UnmarshalJSON(data []byte) error {
+	*r = DocumentSymbolResult{}
+
+	var probe []struct {
+		Location json.RawMessage `json:"location"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+
+	if len(probe) > 0 && probe[0].Location == nil {
+		return json.Unmarshal(data, &r.DocumentSymbols)
+	}
+
+	return json.Unmarshal(data, &r.SymbolInformation)
+}
+
+// DocumentSymbolsToSymbolInformation flattens the DocumentSymbol trees of uri in pre-order.
+//
+// The location of each symbol is its full range, and the container name is the name of its parent.
+func DocumentSymbolsToSymbolInformation(uri DocumentURI, symbols []DocumentSymbol) []SymbolInformation {
+	if symbols == nil {
+		return nil
+	}
+
+	infos := []SymbolInformation{}
+	var walk func(symbols []DocumentSymbol, containerName string)
+	walk = func(symbols []DocumentSymbol, containerName string) {
+		for _, symbol := range symbols {
+			infos = append(infos, SymbolInformation{
+				Name:       symbol.Name,
+				Kind:       symbol.Kind,
+				Tags:       symbol.Tags,
+				Deprecated: symbol.Deprecated,
+				Location: Location{
+					URI:   uri,
+					Range: symbol.Range,
+				},
+				ContainerName: containerName,
+			})
+			walk(symbol.Children, symbol.Name)
+		}
+	}
+	walk(symbols, "")
+
+	return infos
+}
+
+// SymbolInformationToDocumentSymbols nests the SymbolInformation of a single document into DocumentSymbol trees
+// by their container names.
+//
+// The location range of a symbol says nothing about its nesting, so a symbol becomes a child of the closest
+// preceding symbol whose name equals its ContainerName, which is the order DocumentSymbolsToSymbolInformation
+// produces. A symbol whose container is missing, comes later in symbols or is named differently, as with qualified
+// container names, stays at the top level.
+//
+// SymbolInformation carries no selection range, so the SelectionRange of each symbol is the empty range at the
+// start of its Range.
+func SymbolInformationToDocumentSymbols(symbols []SymbolInformation) []DocumentSymbol {
+	if symbols == nil {
+		return nil
+	}
+
+	type node struct {
+		symbol   DocumentSymbol
+		children []*node
+	}
+
+	roots := []*node{}
+	byName := make(map[string]*node)
+	for _, info := range symbols {
+		n := &node{
+			symbol: DocumentSymbol{
+				Name:       info.Name,
+				Kind:       info.Kind,
+				Tags:       info.Tags,
+				Deprecated: info.Deprecated,
+				Range:      info.Location.Range,
+				SelectionRange: Range{
+					Start: info.Location.Range.Start,
+					End:   info.Location.Range.Start,
+				},
+			},
+		}
+		if parent, ok := byName[info.ContainerName]; ok && info.ContainerName != "" {
+			parent.children = append(parent.children, n)
+		} else {
+			roots = append(roots, n)
+		}
+		byName[info.Name] = n
+	}
+
+	var build func(nodes []*node) []DocumentSymbol
+	build = func(nodes []*node) []DocumentSymbol {
+		result := make([]DocumentSymbol, len(nodes))
+		for i, n := range nodes {
+			result[i] = n.symbol
+			if len(n.children) > 0 {
+				result[i].Children = build(n.children)
+			}
+		}
+		return result
+	}
+
+	return build(roots)
+}
+
 // CodeActionParams params for the CodeActionRequest.
 type CodeActionParams struct {
 	WorkDoneProgressParams
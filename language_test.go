@@ -4,6 +4,7 @@
 package protocol
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -294,7 +295,7 @@ func TestCompletionList(t *testing.T) {
 				},
 				Deprecated:       false,
 				Detail:           "string",
-				Documentation:    "Detail a human-readable string with additional information about this item, like type or symbol information.",
+				Documentation:    NewDocumentation("Detail a human-readable string with additional information about this item, like type or symbol information."),
 				FilterText:       "Detail",
 				InsertText:       "",
 				InsertTextFormat: InsertTextFormatSnippet,
@@ -302,18 +303,20 @@ func TestCompletionList(t *testing.T) {
 				Label:            "Detail",
 				Preselect:        true,
 				SortText:         "00000",
-				TextEdit: &TextEdit{
-					Range: Range{
-						Start: Position{
-							Line:      255,
-							Character: 4,
-						},
-						End: Position{
-							Line:      255,
-							Character: 10,
+				TextEdit: &CompletionItemTextEdit{
+					TextEdit: &TextEdit{
+						Range: Range{
+							Start: Position{
+								Line:      255,
+								Character: 4,
+							},
+							End: Position{
+								Line:      255,
+								Character: 10,
+							},
 						},
+						NewText: "Detail: ${1:},",
 					},
-					NewText: "Detail: ${1:},",
 				},
 			},
 		},
@@ -400,6 +403,266 @@ func TestCompletionList(t *testing.T) {
 	})
 }
 
+func TestCompletionListItemDefaults(t *testing.T) {
+	t.Parallel()
+
+	const (
+		want              = `{"commitCharacters":["."],"editRange":{"start":{"line":255,"character":4},"end":{"line":255,"character":10}},"insertTextFormat":2,"insertTextMode":1,"data":"testData"}`
+		wantInsertReplace = `{"editRange":{"insert":{"start":{"line":255,"character":4},"end":{"line":255,"character":6}},"replace":{"start":{"line":255,"character":4},"end":{"line":255,"character":10}}}}`
+		wantNil           = `{}`
+		wantInvalid       = `{"editRange":{"insert":{"start":{"line":255,"character":4},"end":{"line":255,"character":10}},"replace":{"start":{"line":255,"character":4},"end":{"line":255,"character":10}}}}`
+	)
+	wantType := CompletionListItemDefaults{
+		CommitCharacters: []string{"."},
+		EditRange: &CompletionListItemDefaultsEditRange{
+			Range: &Range{
+				Start: Position{Line: 255, Character: 4},
+				End:   Position{Line: 255, Character: 10},
+			},
+		},
+		InsertTextFormat: InsertTextFormatSnippet,
+		InsertTextMode:   InsertTextModeAsIs,
+		Data:             "testData",
+	}
+	wantTypeInsertReplace := CompletionListItemDefaults{
+		EditRange: &CompletionListItemDefaultsEditRange{
+			InsertReplace: &InsertReplaceRange{
+				Insert: Range{
+					Start: Position{Line: 255, Character: 4},
+					End:   Position{Line: 255, Character: 6},
+				},
+				Replace: Range{
+					Start: Position{Line: 255, Character: 4},
+					End:   Position{Line: 255, Character: 10},
+				},
+			},
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          CompletionListItemDefaults
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Valid",
+				field:          wantType,
+				want:           want,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "ValidInsertReplace",
+				field:          wantTypeInsertReplace,
+				want:           wantInsertReplace,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "ValidNilAll",
+				field:          CompletionListItemDefaults{},
+				want:           wantNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantTypeInsertReplace,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+			{
+				name:           "InvalidEmptyEditRange",
+				field:          CompletionListItemDefaults{EditRange: &CompletionListItemDefaultsEditRange{}},
+				want:           wantNil,
+				wantMarshalErr: true,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             CompletionListItemDefaults
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Valid",
+				field:            want,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "ValidInsertReplace",
+				field:            wantInsertReplace,
+				want:             wantTypeInsertReplace,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "ValidNilAll",
+				field:            wantNil,
+				want:             CompletionListItemDefaults{},
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantTypeInsertReplace,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got CompletionListItemDefaults
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+}
+
+func TestCompletionList_ExpandItemDefaults(t *testing.T) {
+	t.Parallel()
+
+	editRange := Range{
+		Start: Position{Line: 255, Character: 4},
+		End:   Position{Line: 255, Character: 10},
+	}
+	newList := func() *CompletionList {
+		return &CompletionList{
+			ItemDefaults: &CompletionListItemDefaults{
+				CommitCharacters: []string{"."},
+				EditRange:        &CompletionListItemDefaultsEditRange{Range: &editRange},
+				InsertTextFormat: InsertTextFormatSnippet,
+				Data:             "testData",
+			},
+			Items: []CompletionItem{
+				{
+					Label: "Detail",
+				},
+				{
+					Label:            "Documentation",
+					TextEditText:     "Documentation: ${1:},",
+					CommitCharacters: []string{","},
+					InsertTextFormat: InsertTextFormatPlainText,
+					Data:             "itemData",
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		supported []string
+		want      *CompletionList
+	}{
+		{
+			name:      "AllSupported",
+			supported: []string{"commitCharacters", "editRange", "insertTextFormat", "insertTextMode", "data"},
+			want:      newList(),
+		},
+		{
+			name:      "NoneSupported",
+			supported: nil,
+			want: &CompletionList{
+				Items: []CompletionItem{
+					{
+						Label:            "Detail",
+						CommitCharacters: []string{"."},
+						TextEdit:         &CompletionItemTextEdit{TextEdit: &TextEdit{Range: editRange, NewText: "Detail"}},
+						InsertTextFormat: InsertTextFormatSnippet,
+						Data:             "testData",
+					},
+					{
+						Label:            "Documentation",
+						TextEdit:         &CompletionItemTextEdit{TextEdit: &TextEdit{Range: editRange, NewText: "Documentation: ${1:},"}},
+						CommitCharacters: []string{","},
+						InsertTextFormat: InsertTextFormatPlainText,
+						Data:             "itemData",
+					},
+				},
+			},
+		},
+		{
+			name:      "PartiallySupported",
+			supported: []string{"editRange"},
+			want: &CompletionList{
+				ItemDefaults: &CompletionListItemDefaults{
+					EditRange: &CompletionListItemDefaultsEditRange{Range: &editRange},
+				},
+				Items: []CompletionItem{
+					{
+						Label:            "Detail",
+						CommitCharacters: []string{"."},
+						InsertTextFormat: InsertTextFormatSnippet,
+						Data:             "testData",
+					},
+					{
+						Label:            "Documentation",
+						TextEditText:     "Documentation: ${1:},",
+						CommitCharacters: []string{","},
+						InsertTextFormat: InsertTextFormatPlainText,
+						Data:             "itemData",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := newList()
+			got.ExpandItemDefaults(tt.supported)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("%s: (-want +got)\n%s", tt.name, diff)
+			}
+		})
+	}
+}
+
 func TestInsertTextFormat_String(t *testing.T) {
 	t.Parallel()
 
@@ -605,7 +868,7 @@ func TestCompletionItem(t *testing.T) {
 		Data:             "testData",
 		Deprecated:       true,
 		Detail:           "string",
-		Documentation:    "Detail a human-readable string with additional information about this item, like type or symbol information.",
+		Documentation:    NewDocumentation("Detail a human-readable string with additional information about this item, like type or symbol information."),
 		FilterText:       "Detail",
 		InsertText:       "testInsert",
 		InsertTextFormat: InsertTextFormatSnippet,
@@ -614,18 +877,20 @@ func TestCompletionItem(t *testing.T) {
 		Label:            "Detail",
 		Preselect:        true,
 		SortText:         "00000",
-		TextEdit: &TextEdit{
-			Range: Range{
-				Start: Position{
-					Line:      255,
-					Character: 4,
-				},
-				End: Position{
-					Line:      255,
-					Character: 10,
+		TextEdit: &CompletionItemTextEdit{
+			TextEdit: &TextEdit{
+				Range: Range{
+					Start: Position{
+						Line:      255,
+						Character: 4,
+					},
+					End: Position{
+						Line:      255,
+						Character: 10,
+					},
 				},
+				NewText: "Detail: ${1:},",
 			},
-			NewText: "Detail: ${1:},",
 		},
 	}
 	wantTypeNilAll := CompletionItem{
@@ -727,6 +992,206 @@ func TestCompletionItem(t *testing.T) {
 	})
 }
 
+func TestCompletionItemTextEdit(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantTextEdit          = `{"range":{"start":{"line":255,"character":4},"end":{"line":255,"character":10}},"newText":"Detail"}`
+		wantInsertReplaceEdit = `{"newText":"Detail","insert":{"start":{"line":255,"character":4},"end":{"line":255,"character":6}},"replace":{"start":{"line":255,"character":4},"end":{"line":255,"character":10}}}`
+		wantInvalid           = `{"range":{"start":{"line":255,"character":4},"end":{"line":255,"character":6}},"newText":"Detail"}`
+	)
+	insert := Range{
+		Start: Position{Line: 255, Character: 4},
+		End:   Position{Line: 255, Character: 6},
+	}
+	replace := Range{
+		Start: Position{Line: 255, Character: 4},
+		End:   Position{Line: 255, Character: 10},
+	}
+	wantTypeTextEdit := CompletionItemTextEdit{
+		TextEdit: &TextEdit{
+			Range:   replace,
+			NewText: "Detail",
+		},
+	}
+	wantTypeInsertReplaceEdit := CompletionItemTextEdit{
+		InsertReplaceEdit: &InsertReplaceEdit{
+			NewText: "Detail",
+			Insert:  insert,
+			Replace: replace,
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          CompletionItemTextEdit
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "ValidTextEdit",
+				field:          wantTypeTextEdit,
+				want:           wantTextEdit,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "ValidInsertReplaceEdit",
+				field:          wantTypeInsertReplaceEdit,
+				want:           wantInsertReplaceEdit,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantTypeInsertReplaceEdit,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+			{
+				name:           "InvalidEmpty",
+				field:          CompletionItemTextEdit{},
+				want:           "",
+				wantMarshalErr: true,
+				wantErr:        false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             CompletionItemTextEdit
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "ValidTextEdit",
+				field:            wantTextEdit,
+				want:             wantTypeTextEdit,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "ValidInsertReplaceEdit",
+				field:            wantInsertReplaceEdit,
+				want:             wantTypeInsertReplaceEdit,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantTypeInsertReplaceEdit,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got CompletionItemTextEdit
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("CollapseTextEdit", func(t *testing.T) {
+		t.Parallel()
+
+		collapsed := &CompletionItemTextEdit{
+			TextEdit: &TextEdit{
+				Range:   insert,
+				NewText: "Detail",
+			},
+		}
+
+		tests := []struct {
+			name string
+			caps *CompletionTextDocumentClientCapabilitiesItem
+			edit *CompletionItemTextEdit
+			want *CompletionItemTextEdit
+		}{
+			{
+				name: "NilCapabilities",
+				caps: nil,
+				edit: &wantTypeInsertReplaceEdit,
+				want: collapsed,
+			},
+			{
+				name: "NoInsertReplaceSupport",
+				caps: &CompletionTextDocumentClientCapabilitiesItem{},
+				edit: &wantTypeInsertReplaceEdit,
+				want: collapsed,
+			},
+			{
+				name: "InsertReplaceSupport",
+				caps: &CompletionTextDocumentClientCapabilitiesItem{InsertReplaceSupport: true},
+				edit: &wantTypeInsertReplaceEdit,
+				want: &wantTypeInsertReplaceEdit,
+			},
+			{
+				name: "TextEdit",
+				caps: nil,
+				edit: &wantTypeTextEdit,
+				want: &wantTypeTextEdit,
+			},
+			{
+				name: "NilTextEdit",
+				caps: nil,
+				edit: nil,
+				want: nil,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				item := CompletionItem{Label: "Detail", TextEdit: tt.edit}
+				item.CollapseTextEdit(tt.caps)
+				if diff := cmp.Diff(tt.want, item.TextEdit); diff != "" {
+					t.Errorf("%s: (-want +got)\n%s", tt.name, diff)
+				}
+			})
+		}
+	})
+}
+
 func TestCompletionItemKind_String(t *testing.T) {
 	t.Parallel()
 
@@ -1164,9 +1629,11 @@ func TestHover(t *testing.T) {
 		wantInvalid = `{"contents":{"kind":"markdown","value":"example value"},"range":{"start":{"line":25,"character":2},"end":{"line":25,"character":5}}}`
 	)
 	wantType := Hover{
-		Contents: MarkupContent{
-			Kind:  Markdown,
-			Value: "example value",
+		Contents: HoverContents{
+			MarkupContent: &MarkupContent{
+				Kind:  Markdown,
+				Value: "example value",
+			},
 		},
 		Range: &Range{
 			Start: Position{
@@ -1229,36 +1696,326 @@ func TestHover(t *testing.T) {
 			wantErr          bool
 		}{
 			{
-				name:             "Valid",
-				field:            want,
-				want:             wantType,
-				wantUnmarshalErr: false,
-				wantErr:          false,
+				name:             "Valid",
+				field:            want,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got Hover
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+}
+
+func TestHoverContents(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantMarkupContent = `{"kind":"markdown","value":"example value"}`
+		wantMarkedString  = `"example value"`
+		wantMarkedCode    = `{"language":"go","value":"func main()"}`
+		wantMarkedStrings = `["example value",{"language":"go","value":"func main()"}]`
+		wantInvalid       = `{"kind":"plaintext","value":"example value"}`
+	)
+	wantTypeMarkupContent := HoverContents{
+		MarkupContent: &MarkupContent{
+			Kind:  Markdown,
+			Value: "example value",
+		},
+	}
+	markedString := NewMarkedString("example value")
+	markedCode := NewMarkedStringCode("go", "func main()")
+	wantTypeMarkedString := HoverContents{
+		MarkedString: &markedString,
+	}
+	wantTypeMarkedCode := HoverContents{
+		MarkedString: &markedCode,
+	}
+	wantTypeMarkedStrings := HoverContents{
+		MarkedStrings: []MarkedString{markedString, markedCode},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          HoverContents
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "MarkupContent",
+				field:          wantTypeMarkupContent,
+				want:           wantMarkupContent,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "MarkedString",
+				field:          wantTypeMarkedString,
+				want:           wantMarkedString,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "MarkedCode",
+				field:          wantTypeMarkedCode,
+				want:           wantMarkedCode,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "MarkedStrings",
+				field:          wantTypeMarkedStrings,
+				want:           wantMarkedStrings,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantTypeMarkupContent,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+			{
+				name:           "InvalidEmpty",
+				field:          HoverContents{},
+				want:           "",
+				wantMarshalErr: true,
+				wantErr:        false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             HoverContents
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "MarkupContent",
+				field:            wantMarkupContent,
+				want:             wantTypeMarkupContent,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "MarkedString",
+				field:            wantMarkedString,
+				want:             wantTypeMarkedString,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "MarkedCode",
+				field:            wantMarkedCode,
+				want:             wantTypeMarkedCode,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "MarkedStrings",
+				field:            wantMarkedStrings,
+				want:             wantTypeMarkedStrings,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantTypeMarkupContent,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got HoverContents
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Normalize", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name          string
+			contents      HoverContents
+			contentFormat []MarkupKind
+			want          MarkupContent
+		}{
+			{
+				name:          "MarkupContent",
+				contents:      wantTypeMarkupContent,
+				contentFormat: []MarkupKind{Markdown, PlainText},
+				want:          MarkupContent{Kind: Markdown, Value: "example value"},
+			},
+			{
+				name:          "MarkupContentPlainText",
+				contents:      wantTypeMarkupContent,
+				contentFormat: []MarkupKind{PlainText},
+				want:          MarkupContent{Kind: PlainText, Value: "example value"},
+			},
+			{
+				name:          "MarkedCodeMarkdown",
+				contents:      wantTypeMarkedCode,
+				contentFormat: []MarkupKind{Markdown},
+				want:          MarkupContent{Kind: Markdown, Value: "```go\nfunc main()\n```"},
+			},
+			{
+				name:          "MarkedStringsMarkdown",
+				contents:      wantTypeMarkedStrings,
+				contentFormat: []MarkupKind{Markdown},
+				want:          MarkupContent{Kind: Markdown, Value: "example value\n\n```go\nfunc main()\n```"},
+			},
+			{
+				name:          "MarkedStringsDefault",
+				contents:      wantTypeMarkedStrings,
+				contentFormat: nil,
+				want:          MarkupContent{Kind: PlainText, Value: "example value\n\nfunc main()"},
+			},
+			{
+				name:          "MarkupContentSupportedKind",
+				contents:      wantTypeMarkupContent,
+				contentFormat: []MarkupKind{PlainText, Markdown},
+				want:          MarkupContent{Kind: Markdown, Value: "example value"},
 			},
 			{
-				name:             "Invalid",
-				field:            wantInvalid,
-				want:             wantType,
-				wantUnmarshalErr: false,
-				wantErr:          true,
+				name:          "PlainTextToMarkdown",
+				contents:      HoverContents{MarkupContent: &MarkupContent{Kind: PlainText, Value: "a *b* <c>"}},
+				contentFormat: []MarkupKind{Markdown},
+				want:          MarkupContent{Kind: Markdown, Value: "a \\*b\\* \\<c\\>"},
+			},
+			{
+				name: "MarkdownToPlainText",
+				contents: HoverContents{MarkupContent: &MarkupContent{
+					Kind:  Markdown,
+					Value: "*call* `main`:\n```go\nfunc main() {\n}\n```\nor\n~~~\nmain()\n~~~",
+				}},
+				contentFormat: []MarkupKind{PlainText},
+				want:          MarkupContent{Kind: PlainText, Value: "*call* `main`:\nfunc main() {\n}\nor\nmain()"},
+			},
+			{
+				name:          "MarkedStringMarkdownToPlainText",
+				contents:      wantTypeMarkedString,
+				contentFormat: []MarkupKind{PlainText},
+				want:          MarkupContent{Kind: PlainText, Value: "example value"},
+			},
+			{
+				name: "MarkedStringsProseToPlainText",
+				contents: HoverContents{MarkedStrings: []MarkedString{
+					NewMarkedString("Returns the sum of a and b."),
+					NewMarkedStringCode("go", "func Add(a, b int) int"),
+				}},
+				contentFormat: nil,
+				want:          MarkupContent{Kind: PlainText, Value: "Returns the sum of a and b.\n\nfunc Add(a, b int) int"},
+			},
+			{
+				name: "MarkupContentProseToPlainText",
+				contents: HoverContents{MarkupContent: &MarkupContent{
+					Kind:  Markdown,
+					Value: "Add returns the sum.\n\n```go\nfunc Add()\n```",
+				}},
+				contentFormat: []MarkupKind{PlainText},
+				want:          MarkupContent{Kind: PlainText, Value: "Add returns the sum.\n\nfunc Add()"},
+			},
+			{
+				name:          "MarkdownEscapesToPlainText",
+				contents:      HoverContents{MarkupContent: &MarkupContent{Kind: Markdown, Value: "just *prose* a \\*b\\* \\<c\\>"}},
+				contentFormat: []MarkupKind{PlainText},
+				want:          MarkupContent{Kind: PlainText, Value: "just *prose* a *b* <c>"},
+			},
+			{
+				name:          "UnknownFormat",
+				contents:      wantTypeMarkedString,
+				contentFormat: []MarkupKind{"html", Markdown},
+				want:          MarkupContent{Kind: Markdown, Value: "example value"},
 			},
 		}
+
 		for _, tt := range tests {
 			tt := tt
 			t.Run(tt.name, func(t *testing.T) {
 				t.Parallel()
 
-				var got Hover
-				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
-					t.Fatal(err)
-				}
-
-				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
-					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				if diff := cmp.Diff(tt.want, tt.contents.Normalize(tt.contentFormat)); diff != "" {
+					t.Errorf("%s: (-want +got)\n%s", tt.name, diff)
 				}
 			})
 		}
 	})
+
+	t.Run("NormalizeContents", func(t *testing.T) {
+		t.Parallel()
+
+		hover := Hover{Contents: wantTypeMarkedCode}
+		hover.NormalizeContents(&HoverTextDocumentClientCapabilities{ContentFormat: []MarkupKind{Markdown}})
+
+		want := Hover{
+			Contents: HoverContents{
+				MarkupContent: &MarkupContent{Kind: Markdown, Value: "```go\nfunc main()\n```"},
+			},
+		}
+		if diff := cmp.Diff(want, hover); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+	})
 }
 
 func TestSignatureHelpParams(t *testing.T) {
@@ -1294,11 +2051,11 @@ func TestSignatureHelpParams(t *testing.T) {
 				Signatures: []SignatureInformation{
 					{
 						Label:         "testLabel",
-						Documentation: "testDocumentation",
+						Documentation: NewDocumentation("testDocumentation"),
 						Parameters: []ParameterInformation{
 							{
 								Label:         "test label",
-								Documentation: "test documentation",
+								Documentation: NewDocumentation("test documentation"),
 							},
 						},
 					},
@@ -1480,11 +2237,11 @@ func TestSignatureHelp(t *testing.T) {
 		Signatures: []SignatureInformation{
 			{
 				Label:         "testLabel",
-				Documentation: "testDocumentation",
+				Documentation: NewDocumentation("testDocumentation"),
 				Parameters: []ParameterInformation{
 					{
 						Label:         "test label",
-						Documentation: "test documentation",
+						Documentation: NewDocumentation("test documentation"),
 					},
 				},
 			},
@@ -1600,11 +2357,11 @@ func TestSignatureInformation(t *testing.T) {
 	)
 	wantType := SignatureInformation{
 		Label:         "testLabel",
-		Documentation: "testDocumentation",
+		Documentation: NewDocumentation("testDocumentation"),
 		Parameters: []ParameterInformation{
 			{
 				Label:         "test label",
-				Documentation: "test documentation",
+				Documentation: NewDocumentation("test documentation"),
 			},
 		},
 		ActiveParameter: uint32(5),
@@ -1700,7 +2457,7 @@ func TestParameterInformation(t *testing.T) {
 	)
 	wantType := ParameterInformation{
 		Label:         "test label",
-		Documentation: "test documentation",
+		Documentation: NewDocumentation("test documentation"),
 	}
 
 	t.Run("Marshal", func(t *testing.T) {
@@ -2816,6 +3573,276 @@ func TestSymbolInformation(t *testing.T) {
 	})
 }
 
+func TestDocumentSymbolResult(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantDocumentSymbols   = `[{"name":"Foo","kind":23,"range":{"start":{"line":1,"character":0},"end":{"line":5,"character":1}},"selectionRange":{"start":{"line":1,"character":5},"end":{"line":1,"character":8}},"children":[{"name":"bar","kind":8,"range":{"start":{"line":2,"character":1},"end":{"line":2,"character":8}},"selectionRange":{"start":{"line":2,"character":1},"end":{"line":2,"character":4}}}]}]`
+		wantSymbolInformation = `[{"name":"Foo","kind":23,"location":{"uri":"file:///path/to/test.go","range":{"start":{"line":1,"character":0},"end":{"line":5,"character":1}}}},{"name":"bar","kind":8,"location":{"uri":"file:///path/to/test.go","range":{"start":{"line":2,"character":1},"end":{"line":2,"character":8}}},"containerName":"Foo"}]`
+		wantNil               = `null`
+	)
+	testURI := uri.File("/path/to/test.go")
+	documentSymbols := []DocumentSymbol{
+		{
+			Name: "Foo",
+			Kind: SymbolKindStruct,
+			Range: Range{
+				Start: Position{Line: 1, Character: 0},
+				End:   Position{Line: 5, Character: 1},
+			},
+			SelectionRange: Range{
+				Start: Position{Line: 1, Character: 5},
+				End:   Position{Line: 1, Character: 8},
+			},
+			Children: []DocumentSymbol{
+				{
+					Name: "bar",
+					Kind: SymbolKindField,
+					Range: Range{
+						Start: Position{Line: 2, Character: 1},
+						End:   Position{Line: 2, Character: 8},
+					},
+					SelectionRange: Range{
+						Start: Position{Line: 2, Character: 1},
+						End:   Position{Line: 2, Character: 4},
+					},
+				},
+			},
+		},
+	}
+	symbolInformation := []SymbolInformation{
+		{
+			Name: "Foo",
+			Kind: SymbolKindStruct,
+			Location: Location{
+				URI: testURI,
+				Range: Range{
+					Start: Position{Line: 1, Character: 0},
+					End:   Position{Line: 5, Character: 1},
+				},
+			},
+		},
+		{
+			Name: "bar",
+			Kind: SymbolKindField,
+			Location: Location{
+				URI: testURI,
+				Range: Range{
+					Start: Position{Line: 2, Character: 1},
+					End:   Position{Line: 2, Character: 8},
+				},
+			},
+			ContainerName: "Foo",
+		},
+	}
+	wantTypeDocumentSymbols := DocumentSymbolResult{DocumentSymbols: documentSymbols}
+	wantTypeSymbolInformation := DocumentSymbolResult{SymbolInformation: symbolInformation}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          DocumentSymbolResult
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "DocumentSymbols",
+				field:          wantTypeDocumentSymbols,
+				want:           wantDocumentSymbols,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "SymbolInformation",
+				field:          wantTypeSymbolInformation,
+				want:           wantSymbolInformation,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Nil",
+				field:          DocumentSymbolResult{},
+				want:           wantNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantTypeDocumentSymbols,
+				want:           wantSymbolInformation,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             DocumentSymbolResult
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "DocumentSymbols",
+				field:            wantDocumentSymbols,
+				want:             wantTypeDocumentSymbols,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "SymbolInformation",
+				field:            wantSymbolInformation,
+				want:             wantTypeSymbolInformation,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Nil",
+				field:            wantNil,
+				want:             DocumentSymbolResult{},
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantSymbolInformation,
+				want:             wantTypeDocumentSymbols,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got DocumentSymbolResult
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("HierarchicalDocumentSymbolSupport", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name string
+			caps *DocumentSymbolClientCapabilities
+			want *DocumentSymbolResult
+		}{
+			{
+				name: "NilCapabilities",
+				caps: nil,
+				want: &wantTypeSymbolInformation,
+			},
+			{
+				name: "Flat",
+				caps: &DocumentSymbolClientCapabilities{},
+				want: &wantTypeSymbolInformation,
+			},
+			{
+				name: "Hierarchical",
+				caps: &DocumentSymbolClientCapabilities{HierarchicalDocumentSymbolSupport: true},
+				want: &wantTypeDocumentSymbols,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				if diff := cmp.Diff(tt.want, tt.caps.DocumentSymbolResult(testURI, documentSymbols)); diff != "" {
+					t.Errorf("%s: (-want +got)\n%s", tt.name, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Hierarchy", func(t *testing.T) {
+		t.Parallel()
+
+		// The selection ranges can't be recovered from SymbolInformation.
+		selectionRange := func(rng Range) Range {
+			return Range{Start: rng.Start, End: rng.Start}
+		}
+		foo := DocumentSymbol{
+			Name:           "Foo",
+			Kind:           SymbolKindStruct,
+			Range:          documentSymbols[0].Range,
+			SelectionRange: selectionRange(documentSymbols[0].Range),
+		}
+		bar := DocumentSymbol{
+			Name:           "bar",
+			Kind:           SymbolKindField,
+			Range:          documentSymbols[0].Children[0].Range,
+			SelectionRange: selectionRange(documentSymbols[0].Children[0].Range),
+		}
+		nested := foo
+		nested.Children = []DocumentSymbol{bar}
+		if diff := cmp.Diff([]DocumentSymbol{nested}, wantTypeSymbolInformation.Hierarchy()); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+
+		// a container that comes after its child is not found.
+		reversed := DocumentSymbolResult{SymbolInformation: []SymbolInformation{symbolInformation[1], symbolInformation[0]}}
+		if diff := cmp.Diff([]DocumentSymbol{bar, foo}, reversed.Hierarchy()); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+
+		// the nesting follows the container names, not the ranges.
+		outside := []SymbolInformation{symbolInformation[0], symbolInformation[1]}
+		outside[1].ContainerName = ""
+		if diff := cmp.Diff([]DocumentSymbol{foo, bar}, SymbolInformationToDocumentSymbols(outside)); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+		if diff := cmp.Diff(documentSymbols, wantTypeDocumentSymbols.Hierarchy()); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+	})
+
+	t.Run("Flat", func(t *testing.T) {
+		t.Parallel()
+
+		if diff := cmp.Diff(symbolInformation, wantTypeDocumentSymbols.Flat(testURI)); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+		if diff := cmp.Diff(symbolInformation, wantTypeSymbolInformation.Flat(testURI)); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+	})
+}
+
 func TestCodeActionParams(t *testing.T) {
 	t.Parallel()
 
@@ -2851,7 +3878,7 @@ func TestCodeActionParams(t *testing.T) {
 						},
 					},
 					Severity: DiagnosticSeverityError,
-					Code:     "foo/bar",
+					Code:     NewStringDiagnosticCode("foo/bar"),
 					Source:   "test foo bar",
 					Message:  "foo bar",
 					RelatedInformation: []DiagnosticRelatedInformation{
@@ -3065,7 +4092,7 @@ func TestCodeActionContext(t *testing.T) {
 					},
 				},
 				Severity: DiagnosticSeverityError,
-				Code:     "foo/bar",
+				Code:     NewStringDiagnosticCode("foo/bar"),
 				Source:   "test foo bar",
 				Message:  "foo bar",
 				RelatedInformation: []DiagnosticRelatedInformation{
@@ -3197,7 +4224,7 @@ func TestCodeAction(t *testing.T) {
 					},
 				},
 				Severity: DiagnosticSeverityError,
-				Code:     "foo/bar",
+				Code:     NewStringDiagnosticCode("foo/bar"),
 				Source:   "test foo bar",
 				Message:  "foo bar",
 				RelatedInformation: []DiagnosticRelatedInformation{
@@ -3242,27 +4269,31 @@ func TestCodeAction(t *testing.T) {
 					},
 				},
 			},
-			DocumentChanges: []TextDocumentEdit{
+			DocumentChanges: []DocumentChange{
 				{
-					TextDocument: OptionalVersionedTextDocumentIdentifier{
-						TextDocumentIdentifier: TextDocumentIdentifier{
-							URI: uri.File("/path/to/test.go"),
+					TextDocumentEdit: &TextDocumentEdit{
+						TextDocument: OptionalVersionedTextDocumentIdentifier{
+							TextDocumentIdentifier: TextDocumentIdentifier{
+								URI: uri.File("/path/to/test.go"),
+							},
+							Version: NewVersion(int32(10)),
 						},
-						Version: NewVersion(int32(10)),
-					},
-					Edits: []TextEdit{
-						{
-							Range: Range{
-								Start: Position{
-									Line:      25,
-									Character: 1,
-								},
-								End: Position{
-									Line:      27,
-									Character: 3,
+						Edits: []TextDocumentEditItem{
+							{
+								TextEdit: &TextEdit{
+									Range: Range{
+										Start: Position{
+											Line:      25,
+											Character: 1,
+										},
+										End: Position{
+											Line:      27,
+											Character: 3,
+										},
+									},
+									NewText: "foo bar",
 								},
 							},
-							NewText: "foo bar",
 						},
 					},
 				},
@@ -3357,6 +4388,74 @@ func TestCodeAction(t *testing.T) {
 	})
 }
 
+// codeActionResolveServer is a Server which only implements the CodeActionResolve method.
+type codeActionResolveServer struct {
+	Server
+
+	params chan *CodeAction
+	result *CodeAction
+}
+
+// CodeActionResolve implements Server.
+func (s *codeActionResolveServer) CodeActionResolve(_ context.Context, params *CodeAction) (*CodeAction, error) {
+	s.params <- params
+
+	return s.result, nil
+}
+
+func TestServerDispatcher_CodeActionResolve(t *testing.T) {
+	t.Parallel()
+
+	params := &CodeAction{
+		Title: "Refactoring",
+		Kind:  RefactorRewrite,
+		Data:  "testData",
+	}
+	want := &CodeAction{
+		Title: "Refactoring",
+		Kind:  RefactorRewrite,
+		Edit: &WorkspaceEdit{
+			Changes: map[uri.URI][]TextEdit{
+				uri.File("/path/to/test.go"): {
+					{
+						Range: Range{
+							Start: Position{
+								Line:      25,
+								Character: 1,
+							},
+							End: Position{
+								Line:      27,
+								Character: 3,
+							},
+						},
+						NewText: "foo bar",
+					},
+				},
+			},
+		},
+		Data: "testData",
+	}
+
+	server := &codeActionResolveServer{
+		params: make(chan *CodeAction, 1),
+		result: want,
+	}
+	dispatcher, _ := newTestConnPair(t, server, nil)
+
+	got, err := dispatcher.CodeActionResolve(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(params, <-server.params); diff != "" {
+		t.Errorf("params: (-want +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("result: (-want +got)\n%s", diff)
+	}
+}
+
 func TestCodeActionRegistrationOptions(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// LineIndex converts between Positions and offsets in the text of a document.
+//
+// Offsets are counted from the start of the text in bytes, runes (UTF-32 code units) or UTF-16 code units.
+// Every conversion runs in O(log n) of the size of the text.
+//
+// Lines are terminated by "\n", "\r\n" or "\r". A LineIndex is immutable and safe for concurrent use.
+type LineIndex struct {
+	text string
+
+	// lines holds the byte offset of the start of each line.
+	lines []int
+
+	// wide holds every rune of the text that is encoded in more than one byte, in order.
+	wide []wideRune
+}
+
+// utf16Len returns the number of UTF-16 code units needed to encode r.
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+
+	return 1
+}
+
+// wideRune is a rune encoded in more than one byte.
+type wideRune struct {
+	offset int // byte offset
+	size   int // length in bytes
+	runes  int // rune offset
+	utf16  int // UTF-16 offset
+}
+
+// NewLineIndex returns the LineIndex of text.
+func NewLineIndex(text string) *LineIndex {
+	x := &LineIndex{
+		text:  text,
+		lines: []int{0},
+	}
+
+	runes, utf16 := 0, 0
+	for i := 0; i < len(text); {
+		if c := text[i]; c < utf8.RuneSelf {
+			switch c {
+			case '\n':
+				x.lines = append(x.lines, i+1)
+			case '\r':
+				if i+1 < len(text) && text[i+1] == '\n' {
+					break
+				}
+				x.lines = append(x.lines, i+1)
+			}
+			i++
+			runes++
+			utf16++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if size > 1 {
+			x.wide = append(x.wide, wideRune{
+				offset: i,
+				size:   size,
+				runes:  runes,
+				utf16:  utf16,
+			})
+		}
+		i += size
+		runes++
+		utf16 += utf16Len(r)
+	}
+
+	return x
+}
+
+// Text returns the indexed text.
+func (x *LineIndex) Text() string {
+	return x.text
+}
+
+// LineCount returns the number of lines in the text.
+func (x *LineIndex) LineCount() int {
+	return len(x.lines)
+}
+
+// Offset returns the byte offset of pos in the text, where pos.Character counts code units of enc.
+// An empty or unknown enc counts UTF-16 code units.
+//
+// A line past the last line maps to the end of the text and a character past the end of its line maps to the end
+// of that line, as described on Position. A character in the middle of a rune maps to the start of the rune.
+func (x *LineIndex) Offset(pos Position, enc PositionEncodingKind) int {
+	if int(pos.Line) >= len(x.lines) {
+		return len(x.text)
+	}
+
+	start, end := x.lines[pos.Line], x.lineEnd(int(pos.Line))
+	offset := x.fromUnits(x.toUnits(start, enc)+int(pos.Character), enc)
+	if offset > end {
+		return end
+	}
+
+	return offset
+}
+
+// Position returns the Position of the byte offset in the text, where the Character counts code units of enc.
+// An empty or unknown enc counts UTF-16 code units.
+//
+// Offsets outside of the text are clamped to it. An offset in the middle of a rune maps to the start of the rune and
+// an offset in the middle of a line terminator maps to the end of its line.
+func (x *LineIndex) Position(offset int, enc PositionEncodingKind) Position {
+	switch {
+	case offset < 0:
+		offset = 0
+	case offset > len(x.text):
+		offset = len(x.text)
+	}
+
+	line := sort.Search(len(x.lines), func(i int) bool { return x.lines[i] > offset }) - 1
+	if end := x.lineEnd(line); offset > end {
+		offset = end
+	}
+
+	return Position{
+		Line:      uint32(line),
+		Character: uint32(x.toUnits(offset, enc) - x.toUnits(x.lines[line], enc)),
+	}
+}
+
+// ByteOffset returns the byte offset of pos in the text, where pos.Character counts UTF-16 code units.
+func (x *LineIndex) ByteOffset(pos Position) int {
+	return x.Offset(pos, PositionEncodingKindUTF16)
+}
+
+// RuneOffset returns the rune offset of pos in the text, where pos.Character counts UTF-16 code units.
+func (x *LineIndex) RuneOffset(pos Position) int {
+	return x.toUnits(x.ByteOffset(pos), PositionEncodingKindUTF32)
+}
+
+// UTF16Offset returns the UTF-16 offset of pos in the text, where pos.Character counts UTF-16 code units.
+func (x *LineIndex) UTF16Offset(pos Position) int {
+	return x.toUnits(x.ByteOffset(pos), PositionEncodingKindUTF16)
+}
+
+// ByteRange returns the byte offsets of the start and end of rng in the text.
+func (x *LineIndex) ByteRange(rng Range) (start, end int) {
+	return x.ByteOffset(rng.Start), x.ByteOffset(rng.End)
+}
+
+// PositionFromByteOffset returns the Position of the byte offset in the text.
+func (x *LineIndex) PositionFromByteOffset(offset int) Position {
+	return x.Position(offset, PositionEncodingKindUTF16)
+}
+
+// PositionFromRuneOffset returns the Position of the rune offset in the text.
+func (x *LineIndex) PositionFromRuneOffset(offset int) Position {
+	return x.Position(x.fromUnits(offset, PositionEncodingKindUTF32), PositionEncodingKindUTF16)
+}
+
+// PositionFromUTF16Offset returns the Position of the UTF-16 offset in the text.
+func (x *LineIndex) PositionFromUTF16Offset(offset int) Position {
+	return x.Position(x.fromUnits(offset, PositionEncodingKindUTF16), PositionEncodingKindUTF16)
+}
+
+// lineEnd returns the byte offset of the end of line, excluding its terminator.
+func (x *LineIndex) lineEnd(line int) int {
+	if line+1 >= len(x.lines) {
+		return len(x.text)
+	}
+
+	end := x.lines[line+1]
+	if end > 0 && x.text[end-1] == '\n' {
+		end--
+	}
+	if end > 0 && x.text[end-1] == '\r' {
+		end--
+	}
+
+	return end
+}
+
+// toUnits converts the byte offset to an offset in code units of enc.
+func (x *LineIndex) toUnits(offset int, enc PositionEncodingKind) int {
+	i := sort.Search(len(x.wide), func(i int) bool { return x.wide[i].offset >= offset })
+	if i == 0 {
+		return offset
+	}
+
+	w := x.wide[i-1]
+	if offset < w.offset+w.size {
+		return w.units(enc)
+	}
+
+	return w.units(enc) + w.width(enc) + offset - (w.offset + w.size)
+}
+
+// fromUnits converts the offset in code units of enc to a byte offset.
+func (x *LineIndex) fromUnits(units int, enc PositionEncodingKind) int {
+	i := sort.Search(len(x.wide), func(i int) bool { return x.wide[i].units(enc) >= units })
+	if i == 0 {
+		return units
+	}
+
+	w := x.wide[i-1]
+	if units < w.units(enc)+w.width(enc) {
+		return w.offset
+	}
+
+	return w.offset + w.size + units - (w.units(enc) + w.width(enc))
+}
+
+// units returns the offset of w in code units of enc.
+func (w wideRune) units(enc PositionEncodingKind) int {
+	switch enc {
+	case PositionEncodingKindUTF8:
+		return w.offset
+	case PositionEncodingKindUTF32:
+		return w.runes
+	default:
+		return w.utf16
+	}
+}
+
+// width returns the length of w in code units of enc.
+func (w wideRune) width(enc PositionEncodingKind) int {
+	switch enc {
+	case PositionEncodingKindUTF8:
+		return w.size
+	case PositionEncodingKindUTF32:
+		return 1
+	default:
+		if w.size == utf8.UTFMax {
+			return 2
+		}
+		return 1
+	}
+}
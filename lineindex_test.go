@@ -0,0 +1,303 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLineIndex_ByteOffset(t *testing.T) {
+	t.Parallel()
+
+	const text = "ab\r\n𝔣c\rd\n"
+
+	tests := []struct {
+		name string
+		pos  Position
+		want int
+	}{
+		{
+			name: "Start",
+			pos:  Position{Line: 0, Character: 0},
+			want: 0,
+		},
+		{
+			name: "EndOfLine",
+			pos:  Position{Line: 0, Character: 2},
+			want: 2,
+		},
+		{
+			name: "PastEndOfLine",
+			pos:  Position{Line: 0, Character: 5},
+			want: 2,
+		},
+		{
+			name: "SurrogatePair",
+			pos:  Position{Line: 1, Character: 2},
+			want: 8,
+		},
+		{
+			name: "CarriageReturn",
+			pos:  Position{Line: 2, Character: 1},
+			want: 11,
+		},
+		{
+			name: "LastLine",
+			pos:  Position{Line: 3, Character: 0},
+			want: 12,
+		},
+		{
+			name: "PastLastLine",
+			pos:  Position{Line: 10, Character: 3},
+			want: 12,
+		},
+	}
+	x := NewLineIndex(text)
+	if got := x.LineCount(); got != 4 {
+		t.Fatalf("LineCount() = %d, want 4", got)
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := x.ByteOffset(tt.pos); got != tt.want {
+				t.Errorf("ByteOffset(%v) = %d, want %d", tt.pos, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineIndex_Offset(t *testing.T) {
+	t.Parallel()
+
+	// "é" is 2 bytes and 1 UTF-16 code unit, "𝔣" is 4 bytes and 2 UTF-16 code units.
+	const text = "aé𝔣b\r\n\rc\n"
+
+	tests := []struct {
+		name string
+		pos  Position
+		enc  PositionEncodingKind
+		want int
+	}{
+		{
+			name: "UTF8",
+			pos:  Position{Line: 0, Character: 7},
+			enc:  PositionEncodingKindUTF8,
+			want: 7,
+		},
+		{
+			name: "UTF16",
+			pos:  Position{Line: 0, Character: 4},
+			enc:  PositionEncodingKindUTF16,
+			want: 7,
+		},
+		{
+			name: "UTF32",
+			pos:  Position{Line: 0, Character: 3},
+			enc:  PositionEncodingKindUTF32,
+			want: 7,
+		},
+		{
+			name: "DefaultIsUTF16",
+			pos:  Position{Line: 0, Character: 4},
+			enc:  "",
+			want: 7,
+		},
+		{
+			name: "MiddleOfSurrogatePair",
+			pos:  Position{Line: 0, Character: 3},
+			enc:  PositionEncodingKindUTF16,
+			want: 3,
+		},
+		{
+			name: "MiddleOfUTF8Sequence",
+			pos:  Position{Line: 0, Character: 2},
+			enc:  PositionEncodingKindUTF8,
+			want: 1,
+		},
+		{
+			name: "PastEndOfLine",
+			pos:  Position{Line: 0, Character: 100},
+			enc:  PositionEncodingKindUTF32,
+			want: 8,
+		},
+		{
+			name: "EmptyLine",
+			pos:  Position{Line: 1, Character: 1},
+			enc:  PositionEncodingKindUTF16,
+			want: 10,
+		},
+		{
+			name: "AfterCarriageReturn",
+			pos:  Position{Line: 2, Character: 1},
+			enc:  PositionEncodingKindUTF16,
+			want: 12,
+		},
+		{
+			name: "TrailingEmptyLine",
+			pos:  Position{Line: 3, Character: 5},
+			enc:  PositionEncodingKindUTF16,
+			want: 13,
+		},
+		{
+			name: "PastLastLine",
+			pos:  Position{Line: 4, Character: 0},
+			enc:  PositionEncodingKindUTF16,
+			want: 13,
+		},
+	}
+	x := NewLineIndex(text)
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := x.Offset(tt.pos, tt.enc); got != tt.want {
+				t.Errorf("Offset(%v, %q) = %d, want %d", tt.pos, tt.enc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineIndex_Position(t *testing.T) {
+	t.Parallel()
+
+	const text = "aé𝔣b\r\n\rc\n"
+
+	tests := []struct {
+		name   string
+		offset int
+		enc    PositionEncodingKind
+		want   Position
+	}{
+		{
+			name:   "UTF8",
+			offset: 7,
+			enc:    PositionEncodingKindUTF8,
+			want:   Position{Line: 0, Character: 7},
+		},
+		{
+			name:   "UTF16",
+			offset: 7,
+			enc:    PositionEncodingKindUTF16,
+			want:   Position{Line: 0, Character: 4},
+		},
+		{
+			name:   "UTF32",
+			offset: 7,
+			enc:    PositionEncodingKindUTF32,
+			want:   Position{Line: 0, Character: 3},
+		},
+		{
+			name:   "MiddleOfRune",
+			offset: 5,
+			enc:    PositionEncodingKindUTF16,
+			want:   Position{Line: 0, Character: 2},
+		},
+		{
+			name:   "MiddleOfRuneUTF8",
+			offset: 2,
+			enc:    PositionEncodingKindUTF8,
+			want:   Position{Line: 0, Character: 1},
+		},
+		{
+			name:   "MiddleOfCRLF",
+			offset: 9,
+			enc:    PositionEncodingKindUTF16,
+			want:   Position{Line: 0, Character: 5},
+		},
+		{
+			name:   "EmptyLine",
+			offset: 10,
+			enc:    PositionEncodingKindUTF16,
+			want:   Position{Line: 1, Character: 0},
+		},
+		{
+			name:   "Negative",
+			offset: -1,
+			enc:    PositionEncodingKindUTF16,
+			want:   Position{Line: 0, Character: 0},
+		},
+		{
+			name:   "PastEnd",
+			offset: 100,
+			enc:    PositionEncodingKindUTF16,
+			want:   Position{Line: 3, Character: 0},
+		},
+	}
+	x := NewLineIndex(text)
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := x.Position(tt.offset, tt.enc); got != tt.want {
+				t.Errorf("Position(%d, %q) = %v, want %v", tt.offset, tt.enc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineIndex_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	const text = "héllo 𝔣 wörld\r\nsecond\rthird 😀😀\n\nlast"
+
+	x := NewLineIndex(text)
+
+	var (
+		pos          Position
+		runes, utf16 int
+	)
+	for offset := 0; offset <= len(text); {
+		if got := x.ByteOffset(pos); got != offset {
+			t.Errorf("ByteOffset(%v) = %d, want %d", pos, got, offset)
+		}
+		if got := x.RuneOffset(pos); got != runes {
+			t.Errorf("RuneOffset(%v) = %d, want %d", pos, got, runes)
+		}
+		if got := x.UTF16Offset(pos); got != utf16 {
+			t.Errorf("UTF16Offset(%v) = %d, want %d", pos, got, utf16)
+		}
+		if got := x.PositionFromByteOffset(offset); got != pos {
+			t.Errorf("PositionFromByteOffset(%d) = %v, want %v", offset, got, pos)
+		}
+		if got := x.PositionFromRuneOffset(runes); got != pos {
+			t.Errorf("PositionFromRuneOffset(%d) = %v, want %v", runes, got, pos)
+		}
+		if got := x.PositionFromUTF16Offset(utf16); got != pos {
+			t.Errorf("PositionFromUTF16Offset(%d) = %v, want %v", utf16, got, pos)
+		}
+		if offset == len(text) {
+			break
+		}
+
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		offset += size
+		runes++
+		utf16 += utf16Len(r)
+		switch {
+		case r == '\r' && offset < len(text) && text[offset] == '\n':
+			offset++
+			runes++
+			utf16++
+			fallthrough
+		case r == '\n', r == '\r':
+			pos = Position{Line: pos.Line + 1}
+		default:
+			pos.Character += uint32(utf16Len(r))
+		}
+	}
+
+	if got := x.LineCount(); got != 5 {
+		t.Errorf("LineCount() = %d, want 5", got)
+	}
+
+	start, end := x.ByteRange(Range{Start: Position{Line: 0, Character: 9}, End: Position{Line: 1, Character: 3}})
+	if want := "wörld\r\nsec"; text[start:end] != want {
+		t.Errorf("ByteRange() = %q, want %q", text[start:end], want)
+	}
+}
@@ -0,0 +1,361 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"strconv"
+)
+
+// NotebookDocument is a notebook document.
+//
+// @since 3.17.0.
+type NotebookDocument struct {
+	// URI is the notebook document's URI.
+	URI URI `json:"uri"`
+
+	// NotebookType is the type of the notebook.
+	NotebookType string `json:"notebookType"`
+
+	// Version is the version number of this document (it will increase after each
+	// change, including undo/redo).
+	Version int32 `json:"version"`
+
+	// Metadata additional metadata stored with the notebook
+	// document.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// Cells is the cells of a notebook.
+	Cells []NotebookCell `json:"cells"`
+}
+
+// Cell returns the cell whose text document is identified by uri, or nil if the notebook has no such cell.
+func (d *NotebookDocument) Cell(uri DocumentURI) *NotebookCell {
+	for i := range d.Cells {
+		if d.Cells[i].Document == uri {
+			return &d.Cells[i]
+		}
+	}
+
+	return nil
+}
+
+// NotebookCellKind is a notebook cell kind.
+//
+// @since 3.17.0.
+type NotebookCellKind float64
+
+// list of NotebookCellKind.
+const (
+	// NotebookCellKindMarkup is a markup-cell is formatted source that is used for display.
+	NotebookCellKindMarkup NotebookCellKind = 1
+
+	// NotebookCellKindCode is a code-cell is source code.
+	NotebookCellKindCode NotebookCellKind = 2
+)
+
+// String implements fmt.Stringer.
+func (k NotebookCellKind) String() string {
+	switch k {
+	case NotebookCellKindMarkup:
+		return "Markup"
+	case NotebookCellKindCode:
+		return "Code"
+	default:
+		return strconv.FormatFloat(float64(k), 'f', -10, 64)
+	}
+}
+
+// ExecutionSummary is the summary of the last execution of a notebook cell.
+//
+// @since 3.17.0.
+type ExecutionSummary struct {
+	// ExecutionOrder a strict monotonically increasing value
+	// indicating the execution order of a cell
+	// inside a notebook.
+	ExecutionOrder uint32 `json:"executionOrder"`
+
+	// Success whether the execution was successful or
+	// not if known by the client.
+	//
+	// A pointer, so that a failed execution is not mistaken for an unknown result.
+	Success *bool `json:"success,omitempty"`
+}
+
+// NotebookCell is a notebook cell.
+//
+// A cell's document URI must be unique across ALL notebook
+// cells and can therefore be used to uniquely identify a
+// notebook cell or the cell's text document.
+//
+// @since 3.17.0.
+type NotebookCell struct {
+	// Kind is the cell's kind.
+	Kind NotebookCellKind `json:"kind"`
+
+	// Document is the URI of the cell's text document
+	// content.
+	Document DocumentURI `json:"document"`
+
+	// Metadata additional metadata stored with the cell.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// ExecutionSummary additional execution summary information
+	// if supported by the client.
+	ExecutionSummary *ExecutionSummary `json:"executionSummary,omitempty"`
+}
+
+// NotebookCellArrayChange is a change describing how to move a NotebookCell
+// array from state S to S'.
+//
+// @since 3.17.0.
+type NotebookCellArrayChange struct {
+	// Start is the start offset of the cell that changed.
+	Start uint32 `json:"start"`
+
+	// DeleteCount is the deleted cells.
+	DeleteCount uint32 `json:"deleteCount"`
+
+	// Cells is the new cells, if any.
+	Cells []NotebookCell `json:"cells,omitempty"`
+}
+
+// NotebookDocumentFilter is a notebook document filter denotes a notebook document by
+// different properties.
+//
+// At least one of NotebookType, Scheme or Pattern must be set.
+//
+// @since 3.17.0.
+type NotebookDocumentFilter struct {
+	// NotebookType is the type of the enclosing notebook.
+	NotebookType string `json:"notebookType,omitempty"`
+
+	// Scheme is a Uri scheme, like "file" or "untitled".
+	Scheme string `json:"scheme,omitempty"`
+
+	// Pattern is a glob pattern.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// NotebookCellTextDocumentFilter is a notebook cell text document filter denotes a cell text
+// document by different properties.
+//
+// @since 3.17.0.
+type NotebookCellTextDocumentFilter struct {
+	// Notebook is a filter that matches against the notebook
+	// containing the notebook cell. If a string
+	// value is provided it matches against the
+	// notebook type. '*' matches every notebook.
+	Notebook interface{} `json:"notebook"` // string | *NotebookDocumentFilter
+
+	// Language is a language id like "python".
+	//
+	// Will be matched against the language id of the
+	// notebook cell document. '*' matches every language.
+	Language string `json:"language,omitempty"`
+}
+
+// NotebookDocumentIdentifier is a literal to identify a notebook document in the client.
+//
+// @since 3.17.0.
+type NotebookDocumentIdentifier struct {
+	// URI is the notebook document's URI.
+	URI URI `json:"uri"`
+}
+
+// VersionedNotebookDocumentIdentifier is a versioned notebook document identifier.
+//
+// @since 3.17.0.
+type VersionedNotebookDocumentIdentifier struct {
+	// Version is the version number of this notebook document.
+	Version int32 `json:"version"`
+
+	// URI is the notebook document's URI.
+	URI URI `json:"uri"`
+}
+
+// DidOpenNotebookDocumentParams params of DidOpenNotebookDocument notification.
+//
+// @since 3.17.0.
+type DidOpenNotebookDocumentParams struct {
+	// NotebookDocument is the notebook document that got opened.
+	NotebookDocument NotebookDocument `json:"notebookDocument"`
+
+	// CellTextDocuments is the text documents that represent the content
+	// of a notebook cell.
+	CellTextDocuments []TextDocumentItem `json:"cellTextDocuments"`
+}
+
+// DidOpenTextDocumentParams returns the DidOpenTextDocument params for each cell text document, so that
+// notebook cells can be handled by the existing text document synchronization.
+func (p *DidOpenNotebookDocumentParams) DidOpenTextDocumentParams() []DidOpenTextDocumentParams {
+	return didOpenCellTextDocumentParams(p.CellTextDocuments)
+}
+
+// DidChangeNotebookDocumentParams params of DidChangeNotebookDocument notification.
+//
+// @since 3.17.0.
+type DidChangeNotebookDocumentParams struct {
+	// NotebookDocument is the notebook document that did change. The version number points
+	// to the version after all provided changes have been applied. If
+	// only the text document content of a cell changes the notebook version
+	// doesn't necessarily have to change.
+	NotebookDocument VersionedNotebookDocumentIdentifier `json:"notebookDocument"`
+
+	// Change is the actual changes to the notebook document.
+	//
+	// The changes describe single state changes to the notebook document.
+	// So if there are two changes c1 (at array index 0) and c2 (at array
+	// index 1) for a notebook in state S then c1 moves the notebook from
+	// S to S' and c2 from S' to S''. So c1 is computed on the state S and
+	// c2 is computed on the state S'.
+	Change NotebookDocumentChangeEvent `json:"change"`
+}
+
+// DidOpenTextDocumentParams returns the DidOpenTextDocument params for each cell text document
+// opened by the structural change, if any.
+func (p *DidChangeNotebookDocumentParams) DidOpenTextDocumentParams() []DidOpenTextDocumentParams {
+	if p.Change.Cells == nil || p.Change.Cells.Structure == nil {
+		return nil
+	}
+
+	return didOpenCellTextDocumentParams(p.Change.Cells.Structure.DidOpen)
+}
+
+// DidChangeTextDocumentParams returns the DidChangeTextDocument params for each cell text document
+// whose content changed.
+func (p *DidChangeNotebookDocumentParams) DidChangeTextDocumentParams() []DidChangeTextDocumentParams {
+	if p.Change.Cells == nil || len(p.Change.Cells.TextContent) == 0 {
+		return nil
+	}
+
+	params := make([]DidChangeTextDocumentParams, len(p.Change.Cells.TextContent))
+	for i, content := range p.Change.Cells.TextContent {
+		params[i] = DidChangeTextDocumentParams{
+			TextDocument:   content.Document,
+			ContentChanges: content.Changes,
+		}
+	}
+
+	return params
+}
+
+// DidCloseTextDocumentParams returns the DidCloseTextDocument params for each cell text document
+// closed by the structural change, if any.
+func (p *DidChangeNotebookDocumentParams) DidCloseTextDocumentParams() []DidCloseTextDocumentParams {
+	if p.Change.Cells == nil || p.Change.Cells.Structure == nil {
+		return nil
+	}
+
+	return didCloseCellTextDocumentParams(p.Change.Cells.Structure.DidClose)
+}
+
+// NotebookDocumentChangeEvent is a change event for a notebook document.
+//
+// @since 3.17.0.
+type NotebookDocumentChangeEvent struct {
+	// Metadata is the changed meta data if any.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// Cells changes to cells.
+	Cells *NotebookDocumentChangeEventCells `json:"cells,omitempty"`
+}
+
+// NotebookDocumentChangeEventCells is the changes to the cells of a notebook document.
+//
+// @since 3.17.0.
+type NotebookDocumentChangeEventCells struct {
+	// Structure changes to the cell structure to add or
+	// remove cells.
+	Structure *NotebookDocumentChangeEventCellsStructure `json:"structure,omitempty"`
+
+	// Data changes to notebook cells properties like its
+	// kind, execution summary or metadata.
+	Data []NotebookCell `json:"data,omitempty"`
+
+	// TextContent changes to the text content of notebook cells.
+	TextContent []NotebookDocumentChangeEventCellsTextContent `json:"textContent,omitempty"`
+}
+
+// NotebookDocumentChangeEventCellsStructure is the structural changes of the NotebookDocumentChangeEventCells.
+//
+// @since 3.17.0.
+type NotebookDocumentChangeEventCellsStructure struct {
+	// Array is the change to the cell array.
+	Array NotebookCellArrayChange `json:"array"`
+
+	// DidOpen additional opened cell text documents.
+	DidOpen []TextDocumentItem `json:"didOpen,omitempty"`
+
+	// DidClose additional closed cell text documents.
+	DidClose []TextDocumentIdentifier `json:"didClose,omitempty"`
+}
+
+// NotebookDocumentChangeEventCellsTextContent is the text content changes of the NotebookDocumentChangeEventCells.
+//
+// @since 3.17.0.
+type NotebookDocumentChangeEventCellsTextContent struct {
+	// Document is the cell text document that did change.
+	Document VersionedTextDocumentIdentifier `json:"document"`
+
+	// Changes is the actual content changes of the cell text document.
+	Changes []TextDocumentContentChangeEvent `json:"changes"`
+}
+
+// DidSaveNotebookDocumentParams params of DidSaveNotebookDocument notification.
+//
+// @since 3.17.0.
+type DidSaveNotebookDocumentParams struct {
+	// NotebookDocument is the notebook document that got saved.
+	NotebookDocument NotebookDocumentIdentifier `json:"notebookDocument"`
+}
+
+// DidCloseNotebookDocumentParams params of DidCloseNotebookDocument notification.
+//
+// @since 3.17.0.
+type DidCloseNotebookDocumentParams struct {
+	// NotebookDocument is the notebook document that got closed.
+	NotebookDocument NotebookDocumentIdentifier `json:"notebookDocument"`
+
+	// CellTextDocuments is the text documents that represent the content
+	// of a notebook cell that got closed.
+	CellTextDocuments []TextDocumentIdentifier `json:"cellTextDocuments"`
+}
+
+// DidCloseTextDocumentParams returns the DidCloseTextDocument params for each cell text document, so that
+// notebook cells can be handled by the existing text document synchronization.
+func (p *DidCloseNotebookDocumentParams) DidCloseTextDocumentParams() []DidCloseTextDocumentParams {
+	return didCloseCellTextDocumentParams(p.CellTextDocuments)
+}
+
+// didOpenCellTextDocumentParams converts cell text documents to DidOpenTextDocument params.
+func didOpenCellTextDocumentParams(items []TextDocumentItem) []DidOpenTextDocumentParams {
+	if len(items) == 0 {
+		return nil
+	}
+
+	params := make([]DidOpenTextDocumentParams, len(items))
+	for i, item := range items {
+		params[i] = DidOpenTextDocumentParams{
+			TextDocument: item,
+		}
+	}
+
+	return params
+}
+
+// didCloseCellTextDocumentParams converts cell text document identifiers to DidCloseTextDocument params.
+func didCloseCellTextDocumentParams(ids []TextDocumentIdentifier) []DidCloseTextDocumentParams {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	params := make([]DidCloseTextDocumentParams, len(ids))
+	for i, id := range ids {
+		params[i] = DidCloseTextDocumentParams{
+			TextDocument: id,
+		}
+	}
+
+	return params
+}
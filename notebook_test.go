@@ -0,0 +1,662 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/segmentio/encoding/json"
+
+	"go.lsp.dev/uri"
+)
+
+const (
+	testNotebookCellURI1 DocumentURI = "vscode-notebook-cell:/path/to/notebook.ipynb#cell1"
+	testNotebookCellURI2 DocumentURI = "vscode-notebook-cell:/path/to/notebook.ipynb#cell2"
+)
+
+func TestNotebookCellKind_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		k    NotebookCellKind
+		want string
+	}{
+		{
+			name: "Markup",
+			k:    NotebookCellKindMarkup,
+			want: "Markup",
+		},
+		{
+			name: "Code",
+			k:    NotebookCellKindCode,
+			want: "Code",
+		},
+		{
+			name: "Unknown",
+			k:    NotebookCellKind(0),
+			want: "0",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.k.String(); got != tt.want {
+				t.Errorf("NotebookCellKind.String() = %v, want %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExecutionSummary(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantSuccess = `{"executionOrder":1,"success":true}`
+		wantFailure = `{"executionOrder":1,"success":false}`
+		wantUnknown = `{"executionOrder":1}`
+	)
+	success, failure := true, false
+	wantTypeSuccess := ExecutionSummary{ExecutionOrder: 1, Success: &success}
+	wantTypeFailure := ExecutionSummary{ExecutionOrder: 1, Success: &failure}
+	wantTypeUnknown := ExecutionSummary{ExecutionOrder: 1}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          ExecutionSummary
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Success",
+				field:          wantTypeSuccess,
+				want:           wantSuccess,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Failure",
+				field:          wantTypeFailure,
+				want:           wantFailure,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Unknown",
+				field:          wantTypeUnknown,
+				want:           wantUnknown,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "InvalidFailure",
+				field:          wantTypeFailure,
+				want:           wantUnknown,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             ExecutionSummary
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Success",
+				field:            wantSuccess,
+				want:             wantTypeSuccess,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Failure",
+				field:            wantFailure,
+				want:             wantTypeFailure,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Unknown",
+				field:            wantUnknown,
+				want:             wantTypeUnknown,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "InvalidFailure",
+				field:            wantFailure,
+				want:             wantTypeUnknown,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got ExecutionSummary
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+}
+
+func TestNotebookDocument(t *testing.T) {
+	t.Parallel()
+
+	success := true
+
+	const (
+		want        = `{"uri":"file:///path/to/notebook.ipynb","notebookType":"jupyter-notebook","version":1,"metadata":{"custom":"testMetadata"},"cells":[{"kind":1,"document":"vscode-notebook-cell:/path/to/notebook.ipynb#cell1"},{"kind":2,"document":"vscode-notebook-cell:/path/to/notebook.ipynb#cell2","executionSummary":{"executionOrder":3,"success":true}}]}`
+		wantNil     = `{"uri":"file:///path/to/notebook.ipynb","notebookType":"jupyter-notebook","version":1,"cells":[]}`
+		wantInvalid = `{"uri":"file:///path/to/invalid.ipynb","notebookType":"invalid","version":0,"cells":[{"kind":0,"document":"vscode-notebook-cell:/path/to/invalid.ipynb#cell1"}]}`
+	)
+	wantType := NotebookDocument{
+		URI:          uri.File("/path/to/notebook.ipynb"),
+		NotebookType: "jupyter-notebook",
+		Version:      1,
+		Metadata: map[string]interface{}{
+			"custom": "testMetadata",
+		},
+		Cells: []NotebookCell{
+			{
+				Kind:     NotebookCellKindMarkup,
+				Document: testNotebookCellURI1,
+			},
+			{
+				Kind:     NotebookCellKindCode,
+				Document: testNotebookCellURI2,
+				ExecutionSummary: &ExecutionSummary{
+					ExecutionOrder: 3,
+					Success:        &success,
+				},
+			},
+		},
+	}
+	wantTypeNil := NotebookDocument{
+		URI:          uri.File("/path/to/notebook.ipynb"),
+		NotebookType: "jupyter-notebook",
+		Version:      1,
+		Cells:        []NotebookCell{},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          NotebookDocument
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Valid",
+				field:          wantType,
+				want:           want,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Nil",
+				field:          wantTypeNil,
+				want:           wantNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantType,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             NotebookDocument
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Valid",
+				field:            want,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Nil",
+				field:            wantNil,
+				want:             wantTypeNil,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got NotebookDocument
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+}
+
+func TestNotebookDocument_Cell(t *testing.T) {
+	t.Parallel()
+
+	doc := NotebookDocument{
+		URI:          uri.File("/path/to/notebook.ipynb"),
+		NotebookType: "jupyter-notebook",
+		Cells: []NotebookCell{
+			{
+				Kind:     NotebookCellKindMarkup,
+				Document: testNotebookCellURI1,
+			},
+			{
+				Kind:     NotebookCellKindCode,
+				Document: testNotebookCellURI2,
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		uri  DocumentURI
+		want *NotebookCell
+	}{
+		{
+			name: "Found",
+			uri:  testNotebookCellURI2,
+			want: &doc.Cells[1],
+		},
+		{
+			name: "NotFound",
+			uri:  uri.File("/path/to/basic.go"),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := doc.Cell(tt.uri); got != tt.want {
+				t.Errorf("NotebookDocument.Cell(%v) = %v, want %v", tt.uri, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDidChangeNotebookDocumentParams(t *testing.T) {
+	t.Parallel()
+
+	const (
+		want        = `{"notebookDocument":{"version":2,"uri":"file:///path/to/notebook.ipynb"},"change":{"cells":{"structure":{"array":{"start":1,"deleteCount":1,"cells":[{"kind":2,"document":"vscode-notebook-cell:/path/to/notebook.ipynb#cell2"}]},"didOpen":[{"uri":"vscode-notebook-cell:/path/to/notebook.ipynb#cell2","languageId":"python","version":1,"text":"print(1)"}],"didClose":[{"uri":"vscode-notebook-cell:/path/to/notebook.ipynb#cell1"}]},"textContent":[{"document":{"uri":"vscode-notebook-cell:/path/to/notebook.ipynb#cell2","version":2},"changes":[{"range":{"start":{"line":0,"character":6},"end":{"line":0,"character":7}},"text":"2"}]}]}}}`
+		wantNil     = `{"notebookDocument":{"version":2,"uri":"file:///path/to/notebook.ipynb"},"change":{}}`
+		wantInvalid = `{"notebookDocument":{"version":0,"uri":"file:///path/to/invalid.ipynb"},"change":{"metadata":{"custom":"invalid"}}}`
+	)
+	wantType := DidChangeNotebookDocumentParams{
+		NotebookDocument: VersionedNotebookDocumentIdentifier{
+			Version: 2,
+			URI:     uri.File("/path/to/notebook.ipynb"),
+		},
+		Change: NotebookDocumentChangeEvent{
+			Cells: &NotebookDocumentChangeEventCells{
+				Structure: &NotebookDocumentChangeEventCellsStructure{
+					Array: NotebookCellArrayChange{
+						Start:       1,
+						DeleteCount: 1,
+						Cells: []NotebookCell{
+							{
+								Kind:     NotebookCellKindCode,
+								Document: testNotebookCellURI2,
+							},
+						},
+					},
+					DidOpen: []TextDocumentItem{
+						{
+							URI:        testNotebookCellURI2,
+							LanguageID: PythonLanguage,
+							Version:    1,
+							Text:       "print(1)",
+						},
+					},
+					DidClose: []TextDocumentIdentifier{
+						{
+							URI: testNotebookCellURI1,
+						},
+					},
+				},
+				TextContent: []NotebookDocumentChangeEventCellsTextContent{
+					{
+						Document: VersionedTextDocumentIdentifier{
+							TextDocumentIdentifier: TextDocumentIdentifier{
+								URI: testNotebookCellURI2,
+							},
+							Version: 2,
+						},
+						Changes: []TextDocumentContentChangeEvent{
+							{
+								Range: &Range{
+									Start: Position{
+										Line:      0,
+										Character: 6,
+									},
+									End: Position{
+										Line:      0,
+										Character: 7,
+									},
+								},
+								Text: "2",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	wantTypeNil := DidChangeNotebookDocumentParams{
+		NotebookDocument: VersionedNotebookDocumentIdentifier{
+			Version: 2,
+			URI:     uri.File("/path/to/notebook.ipynb"),
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          DidChangeNotebookDocumentParams
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Valid",
+				field:          wantType,
+				want:           want,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Nil",
+				field:          wantTypeNil,
+				want:           wantNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantType,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             DidChangeNotebookDocumentParams
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Valid",
+				field:            want,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Nil",
+				field:            wantNil,
+				want:             wantTypeNil,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got DidChangeNotebookDocumentParams
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("CellTextDocumentParams", func(t *testing.T) {
+		t.Parallel()
+
+		wantOpen := []DidOpenTextDocumentParams{
+			{
+				TextDocument: wantType.Change.Cells.Structure.DidOpen[0],
+			},
+		}
+		if diff := cmp.Diff(wantOpen, wantType.DidOpenTextDocumentParams()); diff != "" {
+			t.Errorf("DidOpenTextDocumentParams: (-want +got)\n%s", diff)
+		}
+
+		wantChange := []DidChangeTextDocumentParams{
+			{
+				TextDocument:   wantType.Change.Cells.TextContent[0].Document,
+				ContentChanges: wantType.Change.Cells.TextContent[0].Changes,
+			},
+		}
+		if diff := cmp.Diff(wantChange, wantType.DidChangeTextDocumentParams()); diff != "" {
+			t.Errorf("DidChangeTextDocumentParams: (-want +got)\n%s", diff)
+		}
+
+		wantClose := []DidCloseTextDocumentParams{
+			{
+				TextDocument: TextDocumentIdentifier{
+					URI: testNotebookCellURI1,
+				},
+			},
+		}
+		if diff := cmp.Diff(wantClose, wantType.DidCloseTextDocumentParams()); diff != "" {
+			t.Errorf("DidCloseTextDocumentParams: (-want +got)\n%s", diff)
+		}
+
+		if got := wantTypeNil.DidOpenTextDocumentParams(); got != nil {
+			t.Errorf("DidOpenTextDocumentParams: want nil, got %v", got)
+		}
+		if got := wantTypeNil.DidChangeTextDocumentParams(); got != nil {
+			t.Errorf("DidChangeTextDocumentParams: want nil, got %v", got)
+		}
+		if got := wantTypeNil.DidCloseTextDocumentParams(); got != nil {
+			t.Errorf("DidCloseTextDocumentParams: want nil, got %v", got)
+		}
+	})
+}
+
+func TestDidOpenNotebookDocumentParams_DidOpenTextDocumentParams(t *testing.T) {
+	t.Parallel()
+
+	params := DidOpenNotebookDocumentParams{
+		NotebookDocument: NotebookDocument{
+			URI:          uri.File("/path/to/notebook.ipynb"),
+			NotebookType: "jupyter-notebook",
+			Version:      1,
+			Cells: []NotebookCell{
+				{
+					Kind:     NotebookCellKindCode,
+					Document: testNotebookCellURI1,
+				},
+			},
+		},
+		CellTextDocuments: []TextDocumentItem{
+			{
+				URI:        testNotebookCellURI1,
+				LanguageID: PythonLanguage,
+				Version:    1,
+				Text:       "import os",
+			},
+		},
+	}
+	want := []DidOpenTextDocumentParams{
+		{
+			TextDocument: TextDocumentItem{
+				URI:        testNotebookCellURI1,
+				LanguageID: PythonLanguage,
+				Version:    1,
+				Text:       "import os",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, params.DidOpenTextDocumentParams()); diff != "" {
+		t.Errorf("(-want +got)\n%s", diff)
+	}
+}
+
+func TestDidCloseNotebookDocumentParams_DidCloseTextDocumentParams(t *testing.T) {
+	t.Parallel()
+
+	params := DidCloseNotebookDocumentParams{
+		NotebookDocument: NotebookDocumentIdentifier{
+			URI: uri.File("/path/to/notebook.ipynb"),
+		},
+		CellTextDocuments: []TextDocumentIdentifier{
+			{
+				URI: testNotebookCellURI1,
+			},
+			{
+				URI: testNotebookCellURI2,
+			},
+		},
+	}
+	want := []DidCloseTextDocumentParams{
+		{
+			TextDocument: TextDocumentIdentifier{
+				URI: testNotebookCellURI1,
+			},
+		},
+		{
+			TextDocument: TextDocumentIdentifier{
+				URI: testNotebookCellURI2,
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, params.DidCloseTextDocumentParams()); diff != "" {
+		t.Errorf("(-want +got)\n%s", diff)
+	}
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"go.lsp.dev/jsonrpc2"
+)
+
+// newTestConnPair connects server and client over an in-memory pipe and returns the dispatchers of each side.
+//
+// The returned Server dispatches requests to server, and the returned Client dispatches requests to client.
+func newTestConnPair(t *testing.T, server Server, client Client) (Server, Client) {
+	t.Helper()
+
+	serverPipe, clientPipe := net.Pipe()
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	_, serverConn, clientDispatcher := NewServer(ctx, server, jsonrpc2.NewStream(serverPipe), logger)
+	_, clientConn, serverDispatcher := NewClient(ctx, client, jsonrpc2.NewStream(clientPipe), logger)
+
+	t.Cleanup(func() {
+		clientConn.Close()
+		serverConn.Close()
+		<-clientConn.Done()
+		<-serverConn.Done()
+	})
+
+	return serverDispatcher, clientDispatcher
+}
@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/segmentio/encoding/json"
+
+	"go.lsp.dev/uri"
+)
+
+func TestSelectionRange(t *testing.T) {
+	t.Parallel()
+
+	const (
+		want        = `{"range":{"start":{"line":25,"character":5},"end":{"line":25,"character":8}},"parent":{"range":{"start":{"line":25,"character":1},"end":{"line":25,"character":20}},"parent":{"range":{"start":{"line":24,"character":0},"end":{"line":27,"character":1}}}}}`
+		wantNil     = `{"range":{"start":{"line":25,"character":5},"end":{"line":25,"character":8}}}`
+		wantInvalid = `{"range":{"start":{"line":25,"character":5},"end":{"line":25,"character":8}},"parent":{"range":{"start":{"line":24,"character":0},"end":{"line":27,"character":1}}}}`
+	)
+	wantType := SelectionRange{
+		Range: Range{
+			Start: Position{
+				Line:      25,
+				Character: 5,
+			},
+			End: Position{
+				Line:      25,
+				Character: 8,
+			},
+		},
+		Parent: &SelectionRange{
+			Range: Range{
+				Start: Position{
+					Line:      25,
+					Character: 1,
+				},
+				End: Position{
+					Line:      25,
+					Character: 20,
+				},
+			},
+			Parent: &SelectionRange{
+				Range: Range{
+					Start: Position{
+						Line:      24,
+						Character: 0,
+					},
+					End: Position{
+						Line:      27,
+						Character: 1,
+					},
+				},
+			},
+		},
+	}
+	wantTypeNil := SelectionRange{
+		Range: Range{
+			Start: Position{
+				Line:      25,
+				Character: 5,
+			},
+			End: Position{
+				Line:      25,
+				Character: 8,
+			},
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          SelectionRange
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Valid",
+				field:          wantType,
+				want:           want,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Nil",
+				field:          wantTypeNil,
+				want:           wantNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantType,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             SelectionRange
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Valid",
+				field:            want,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Nil",
+				field:            wantNil,
+				want:             wantTypeNil,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got SelectionRange
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+}
+
+// selectionRangeServer is a Server which only implements the SelectionRange method.
+type selectionRangeServer struct {
+	Server
+
+	params chan *SelectionRangeParams
+	result []SelectionRange
+}
+
+// SelectionRange implements Server.
+func (s *selectionRangeServer) SelectionRange(_ context.Context, params *SelectionRangeParams) ([]SelectionRange, error) {
+	s.params <- params
+
+	return s.result, nil
+}
+
+func TestServerDispatcher_SelectionRange(t *testing.T) {
+	t.Parallel()
+
+	params := &SelectionRangeParams{
+		TextDocument: TextDocumentIdentifier{
+			URI: uri.File("/path/to/basic.go"),
+		},
+		Positions: []Position{
+			{
+				Line:      25,
+				Character: 6,
+			},
+			{
+				Line:      30,
+				Character: 2,
+			},
+		},
+	}
+	want := []SelectionRange{
+		{
+			Range: Range{
+				Start: Position{Line: 25, Character: 5},
+				End:   Position{Line: 25, Character: 8},
+			},
+			Parent: &SelectionRange{
+				Range: Range{
+					Start: Position{Line: 25, Character: 1},
+					End:   Position{Line: 25, Character: 20},
+				},
+				Parent: &SelectionRange{
+					Range: Range{
+						Start: Position{Line: 24, Character: 0},
+						End:   Position{Line: 27, Character: 1},
+					},
+				},
+			},
+		},
+		{
+			Range: Range{
+				Start: Position{Line: 30, Character: 1},
+				End:   Position{Line: 30, Character: 4},
+			},
+		},
+	}
+
+	server := &selectionRangeServer{
+		params: make(chan *SelectionRangeParams, 1),
+		result: want,
+	}
+	dispatcher, _ := newTestConnPair(t, server, nil)
+
+	got, err := dispatcher.SelectionRange(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(params, <-server.params, cmpopts.IgnoreTypes(WorkDoneProgressParams{}, PartialResultParams{})); diff != "" {
+		t.Errorf("params: (-want +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("result: (-want +got)\n%s", diff)
+	}
+
+	depth := 0
+	for r := &got[0]; r != nil; r = r.Parent {
+		depth++
+	}
+	if depth != 3 {
+		t.Errorf("parent chain depth = %d, want 3", depth)
+	}
+}
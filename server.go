@@ -159,6 +159,18 @@ func serverDispatch(ctx context.Context, server Server, reply jsonrpc2.Replier,
 
 		return true, reply(ctx, resp, err)
 
+	case MethodCodeActionResolve: // request
+		defer logger.Debug(MethodCodeActionResolve, zap.Error(err))
+
+		var params CodeAction
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		resp, err := server.CodeActionResolve(ctx, &params)
+
+		return true, reply(ctx, resp, err)
+
 	case MethodTextDocumentCodeLens: // request
 		defer logger.Debug(MethodTextDocumentCodeLens, zap.Error(err))
 
@@ -411,6 +423,18 @@ func serverDispatch(ctx context.Context, server Server, reply jsonrpc2.Replier,
 
 		return true, reply(ctx, resp, err)
 
+	case MethodTextDocumentSelectionRange: // request
+		defer logger.Debug(MethodTextDocumentSelectionRange, zap.Error(err))
+
+		var params SelectionRangeParams
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		resp, err := server.SelectionRange(ctx, &params)
+
+		return true, reply(ctx, resp, err)
+
 	case MethodTextDocumentFormatting: // request
 		defer logger.Debug(MethodTextDocumentFormatting, zap.Error(err))
 
@@ -531,6 +555,18 @@ func serverDispatch(ctx context.Context, server Server, reply jsonrpc2.Replier,
 
 		return true, reply(ctx, resp, err)
 
+	case MethodWorkspaceSymbolResolve: // request
+		defer logger.Debug(MethodWorkspaceSymbolResolve, zap.Error(err))
+
+		var params WorkspaceSymbol
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		resp, err := server.WorkspaceSymbolResolve(ctx, &params)
+
+		return true, reply(ctx, resp, err)
+
 	case MethodTextDocumentTypeDefinition: // request
 		defer logger.Debug(MethodTextDocumentTypeDefinition, zap.Error(err))
 
@@ -567,18 +603,6 @@ func serverDispatch(ctx context.Context, server Server, reply jsonrpc2.Replier,
 
 		return true, reply(ctx, resp, err)
 
-	case MethodShowDocument: // request
-		defer logger.Debug(MethodShowDocument, zap.Error(err))
-
-		var params ShowDocumentParams
-		if err := dec.Decode(&params); err != nil {
-			return true, replyParseError(ctx, reply, err)
-		}
-
-		resp, err := server.ShowDocument(ctx, &params)
-
-		return true, reply(ctx, resp, err)
-
 	case MethodWillCreateFiles: // request
 		defer logger.Debug(MethodWillCreateFiles, zap.Error(err))
 
@@ -651,17 +675,6 @@ func serverDispatch(ctx context.Context, server Server, reply jsonrpc2.Replier,
 
 		return true, reply(ctx, nil, err)
 
-	case MethodCodeLensRefresh: // request
-		defer logger.Debug(MethodCodeLensRefresh, zap.Error(err))
-
-		if len(req.Params()) > 0 {
-			return true, reply(ctx, nil, fmt.Errorf("expected no params: %w", jsonrpc2.ErrInvalidParams))
-		}
-
-		err := server.CodeLensRefresh(ctx)
-
-		return true, reply(ctx, nil, err)
-
 	case MethodTextDocumentPrepareCallHierarchy: // request
 		defer logger.Debug(MethodTextDocumentPrepareCallHierarchy, zap.Error(err))
 
@@ -734,17 +747,6 @@ func serverDispatch(ctx context.Context, server Server, reply jsonrpc2.Replier,
 
 		return true, reply(ctx, resp, err)
 
-	case MethodSemanticTokensRefresh: // request
-		defer logger.Debug(MethodSemanticTokensRefresh, zap.Error(err))
-
-		if len(req.Params()) > 0 {
-			return true, reply(ctx, nil, fmt.Errorf("expected no params: %w", jsonrpc2.ErrInvalidParams))
-		}
-
-		err := server.SemanticTokensRefresh(ctx)
-
-		return true, reply(ctx, nil, err)
-
 	case MethodLinkedEditingRange: // request
 		defer logger.Debug(MethodLinkedEditingRange, zap.Error(err))
 
@@ -769,6 +771,150 @@ func serverDispatch(ctx context.Context, server Server, reply jsonrpc2.Replier,
 
 		return true, reply(ctx, resp, err)
 
+	case MethodTextDocumentInlayHint: // request
+		defer logger.Debug(MethodTextDocumentInlayHint, zap.Error(err))
+
+		var params InlayHintParams
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		resp, err := server.InlayHint(ctx, &params)
+
+		return true, reply(ctx, resp, err)
+
+	case MethodInlayHintResolve: // request
+		defer logger.Debug(MethodInlayHintResolve, zap.Error(err))
+
+		var params InlayHint
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		resp, err := server.InlayHintResolve(ctx, &params)
+
+		return true, reply(ctx, resp, err)
+
+	case MethodTextDocumentDiagnostic: // request
+		defer logger.Debug(MethodTextDocumentDiagnostic, zap.Error(err))
+
+		var params DocumentDiagnosticParams
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		resp, err := server.Diagnostic(ctx, &params)
+
+		return true, reply(ctx, resp, err)
+
+	case MethodWorkspaceDiagnostic: // request
+		defer logger.Debug(MethodWorkspaceDiagnostic, zap.Error(err))
+
+		var params WorkspaceDiagnosticParams
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		resp, err := server.WorkspaceDiagnostic(ctx, &params)
+
+		return true, reply(ctx, resp, err)
+
+	case MethodTextDocumentPrepareTypeHierarchy: // request
+		defer logger.Debug(MethodTextDocumentPrepareTypeHierarchy, zap.Error(err))
+
+		var params TypeHierarchyPrepareParams
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		resp, err := server.PrepareTypeHierarchy(ctx, &params)
+
+		return true, reply(ctx, resp, err)
+
+	case MethodTypeHierarchySupertypes: // request
+		defer logger.Debug(MethodTypeHierarchySupertypes, zap.Error(err))
+
+		var params TypeHierarchySupertypesParams
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		resp, err := server.Supertypes(ctx, &params)
+
+		return true, reply(ctx, resp, err)
+
+	case MethodTypeHierarchySubtypes: // request
+		defer logger.Debug(MethodTypeHierarchySubtypes, zap.Error(err))
+
+		var params TypeHierarchySubtypesParams
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		resp, err := server.Subtypes(ctx, &params)
+
+		return true, reply(ctx, resp, err)
+
+	case MethodNotebookDocumentDidOpen: // notification
+		defer logger.Debug(MethodNotebookDocumentDidOpen, zap.Error(err))
+
+		var params DidOpenNotebookDocumentParams
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		err := server.DidOpenNotebookDocument(ctx, &params)
+
+		return true, reply(ctx, nil, err)
+
+	case MethodNotebookDocumentDidChange: // notification
+		defer logger.Debug(MethodNotebookDocumentDidChange, zap.Error(err))
+
+		var params DidChangeNotebookDocumentParams
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		err := server.DidChangeNotebookDocument(ctx, &params)
+
+		return true, reply(ctx, nil, err)
+
+	case MethodNotebookDocumentDidSave: // notification
+		defer logger.Debug(MethodNotebookDocumentDidSave, zap.Error(err))
+
+		var params DidSaveNotebookDocumentParams
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		err := server.DidSaveNotebookDocument(ctx, &params)
+
+		return true, reply(ctx, nil, err)
+
+	case MethodNotebookDocumentDidClose: // notification
+		defer logger.Debug(MethodNotebookDocumentDidClose, zap.Error(err))
+
+		var params DidCloseNotebookDocumentParams
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		err := server.DidCloseNotebookDocument(ctx, &params)
+
+		return true, reply(ctx, nil, err)
+
+	case MethodTextDocumentInlineValue: // request
+		defer logger.Debug(MethodTextDocumentInlineValue, zap.Error(err))
+
+		var params InlineValueParams
+		if err := dec.Decode(&params); err != nil {
+			return true, replyParseError(ctx, reply, err)
+		}
+
+		resp, err := server.InlineValue(ctx, &params)
+
+		return true, reply(ctx, resp, err)
+
 	default:
 		return false, nil
 	}
@@ -784,13 +930,14 @@ type Server interface {
 	LogTrace(ctx context.Context, params *LogTraceParams) (err error)
 	SetTrace(ctx context.Context, params *SetTraceParams) (err error)
 	CodeAction(ctx context.Context, params *CodeActionParams) (result []CodeAction, err error)
+	CodeActionResolve(ctx context.Context, params *CodeAction) (result *CodeAction, err error)
 	CodeLens(ctx context.Context, params *CodeLensParams) (result []CodeLens, err error)
 	CodeLensResolve(ctx context.Context, params *CodeLens) (result *CodeLens, err error)
 	ColorPresentation(ctx context.Context, params *ColorPresentationParams) (result []ColorPresentation, err error)
 	Completion(ctx context.Context, params *CompletionParams) (result *CompletionList, err error)
 	CompletionResolve(ctx context.Context, params *CompletionItem) (result *CompletionItem, err error)
-	Declaration(ctx context.Context, params *DeclarationParams) (result []Location /* Declaration | DeclarationLink[] | null */, err error)
-	Definition(ctx context.Context, params *DefinitionParams) (result []Location /* Definition | DefinitionLink[] | null */, err error)
+	Declaration(ctx context.Context, params *DeclarationParams) (result *LocationResult /* Declaration | DeclarationLink[] | null */, err error)
+	Definition(ctx context.Context, params *DefinitionParams) (result *LocationResult /* Definition | DefinitionLink[] | null */, err error)
 	DidChange(ctx context.Context, params *DidChangeTextDocumentParams) (err error)
 	DidChangeConfiguration(ctx context.Context, params *DidChangeConfigurationParams) (err error)
 	DidChangeWatchedFiles(ctx context.Context, params *DidChangeWatchedFilesParams) (err error)
@@ -802,39 +949,62 @@ type Server interface {
 	DocumentHighlight(ctx context.Context, params *DocumentHighlightParams) (result []DocumentHighlight, err error)
 	DocumentLink(ctx context.Context, params *DocumentLinkParams) (result []DocumentLink, err error)
 	DocumentLinkResolve(ctx context.Context, params *DocumentLink) (result *DocumentLink, err error)
-	DocumentSymbol(ctx context.Context, params *DocumentSymbolParams) (result []interface{} /* []SymbolInformation | []DocumentSymbol */, err error)
+	DocumentSymbol(ctx context.Context, params *DocumentSymbolParams) (result *DocumentSymbolResult /* []SymbolInformation | []DocumentSymbol */, err error)
 	ExecuteCommand(ctx context.Context, params *ExecuteCommandParams) (result interface{}, err error)
 	FoldingRanges(ctx context.Context, params *FoldingRangeParams) (result []FoldingRange, err error)
+	SelectionRange(ctx context.Context, params *SelectionRangeParams) (result []SelectionRange, err error)
 	Formatting(ctx context.Context, params *DocumentFormattingParams) (result []TextEdit, err error)
 	Hover(ctx context.Context, params *HoverParams) (result *Hover, err error)
-	Implementation(ctx context.Context, params *ImplementationParams) (result []Location, err error)
+	Implementation(ctx context.Context, params *ImplementationParams) (result *LocationResult, err error)
 	OnTypeFormatting(ctx context.Context, params *DocumentOnTypeFormattingParams) (result []TextEdit, err error)
 	PrepareRename(ctx context.Context, params *PrepareRenameParams) (result *Range, err error)
 	RangeFormatting(ctx context.Context, params *DocumentRangeFormattingParams) (result []TextEdit, err error)
 	References(ctx context.Context, params *ReferenceParams) (result []Location, err error)
 	Rename(ctx context.Context, params *RenameParams) (result *WorkspaceEdit, err error)
 	SignatureHelp(ctx context.Context, params *SignatureHelpParams) (result *SignatureHelp, err error)
-	Symbols(ctx context.Context, params *WorkspaceSymbolParams) (result []SymbolInformation, err error)
-	TypeDefinition(ctx context.Context, params *TypeDefinitionParams) (result []Location, err error)
+	Symbols(ctx context.Context, params *WorkspaceSymbolParams) (result *WorkspaceSymbolResult, err error)
+	WorkspaceSymbolResolve(ctx context.Context, params *WorkspaceSymbol) (result *WorkspaceSymbol, err error)
+	TypeDefinition(ctx context.Context, params *TypeDefinitionParams) (result *LocationResult, err error)
 	WillSave(ctx context.Context, params *WillSaveTextDocumentParams) (err error)
 	WillSaveWaitUntil(ctx context.Context, params *WillSaveTextDocumentParams) (result []TextEdit, err error)
+
+	// Deprecated: "window/showDocument" is a server to client request. Use Client.ShowDocument instead.
 	ShowDocument(ctx context.Context, params *ShowDocumentParams) (result *ShowDocumentResult, err error)
+
 	WillCreateFiles(ctx context.Context, params *CreateFilesParams) (result *WorkspaceEdit, err error)
 	DidCreateFiles(ctx context.Context, params *CreateFilesParams) (err error)
 	WillRenameFiles(ctx context.Context, params *RenameFilesParams) (result *WorkspaceEdit, err error)
 	DidRenameFiles(ctx context.Context, params *RenameFilesParams) (err error)
 	WillDeleteFiles(ctx context.Context, params *DeleteFilesParams) (result *WorkspaceEdit, err error)
 	DidDeleteFiles(ctx context.Context, params *DeleteFilesParams) (err error)
+
+	// Deprecated: "workspace/codeLens/refresh" is a server to client request. Use Client.CodeLensRefresh instead.
 	CodeLensRefresh(ctx context.Context) (err error)
+
 	PrepareCallHierarchy(ctx context.Context, params *CallHierarchyPrepareParams) (result []CallHierarchyItem, err error)
 	IncomingCalls(ctx context.Context, params *CallHierarchyIncomingCallsParams) (result []CallHierarchyIncomingCall, err error)
 	OutgoingCalls(ctx context.Context, params *CallHierarchyOutgoingCallsParams) (result []CallHierarchyOutgoingCall, err error)
 	SemanticTokensFull(ctx context.Context, params *SemanticTokensParams) (result *SemanticTokens, err error)
 	SemanticTokensFullDelta(ctx context.Context, params *SemanticTokensDeltaParams) (result interface{} /* SemanticTokens | SemanticTokensDelta */, err error)
 	SemanticTokensRange(ctx context.Context, params *SemanticTokensRangeParams) (result *SemanticTokens, err error)
+
+	// Deprecated: "workspace/semanticTokens/refresh" is a server to client request. Use Client.SemanticTokensRefresh instead.
 	SemanticTokensRefresh(ctx context.Context) (err error)
+
 	LinkedEditingRange(ctx context.Context, params *LinkedEditingRangeParams) (result *LinkedEditingRanges, err error)
 	Moniker(ctx context.Context, params *MonikerParams) (result []Moniker, err error)
+	InlayHint(ctx context.Context, params *InlayHintParams) (result []InlayHint, err error)
+	InlayHintResolve(ctx context.Context, params *InlayHint) (result *InlayHint, err error)
+	Diagnostic(ctx context.Context, params *DocumentDiagnosticParams) (result *DocumentDiagnosticReport, err error)
+	WorkspaceDiagnostic(ctx context.Context, params *WorkspaceDiagnosticParams) (result *WorkspaceDiagnosticReport, err error)
+	PrepareTypeHierarchy(ctx context.Context, params *TypeHierarchyPrepareParams) (result []TypeHierarchyItem, err error)
+	Supertypes(ctx context.Context, params *TypeHierarchySupertypesParams) (result []TypeHierarchyItem, err error)
+	Subtypes(ctx context.Context, params *TypeHierarchySubtypesParams) (result []TypeHierarchyItem, err error)
+	DidOpenNotebookDocument(ctx context.Context, params *DidOpenNotebookDocumentParams) (err error)
+	DidChangeNotebookDocument(ctx context.Context, params *DidChangeNotebookDocumentParams) (err error)
+	DidSaveNotebookDocument(ctx context.Context, params *DidSaveNotebookDocumentParams) (err error)
+	DidCloseNotebookDocument(ctx context.Context, params *DidCloseNotebookDocumentParams) (err error)
+	InlineValue(ctx context.Context, params *InlineValueParams) (result []InlineValue, err error)
 	Request(ctx context.Context, method string, params interface{}) (result interface{}, err error)
 }
 
@@ -867,6 +1037,9 @@ const (
 	// MethodTextDocumentCodeAction method name of "textDocument/codeAction".
 	MethodTextDocumentCodeAction = "textDocument/codeAction"
 
+	// MethodCodeActionResolve method name of "codeAction/resolve".
+	MethodCodeActionResolve = "codeAction/resolve"
+
 	// MethodTextDocumentCodeLens method name of "textDocument/codeLens".
 	MethodTextDocumentCodeLens = "textDocument/codeLens"
 
@@ -930,6 +1103,9 @@ const (
 	// MethodTextDocumentFoldingRange method name of "textDocument/foldingRange".
 	MethodTextDocumentFoldingRange = "textDocument/foldingRange"
 
+	// MethodTextDocumentSelectionRange method name of "textDocument/selectionRange".
+	MethodTextDocumentSelectionRange = "textDocument/selectionRange"
+
 	// MethodTextDocumentFormatting method name of "textDocument/formatting".
 	MethodTextDocumentFormatting = "textDocument/formatting"
 
@@ -960,6 +1136,9 @@ const (
 	// MethodWorkspaceSymbol method name of "workspace/symbol".
 	MethodWorkspaceSymbol = "workspace/symbol"
 
+	// MethodWorkspaceSymbolResolve method name of "workspaceSymbol/resolve".
+	MethodWorkspaceSymbolResolve = "workspaceSymbol/resolve"
+
 	// MethodTextDocumentTypeDefinition method name of "textDocument/typeDefinition".
 	MethodTextDocumentTypeDefinition = "textDocument/typeDefinition"
 
@@ -969,9 +1148,6 @@ const (
 	// MethodTextDocumentWillSaveWaitUntil method name of "textDocument/willSaveWaitUntil".
 	MethodTextDocumentWillSaveWaitUntil = "textDocument/willSaveWaitUntil"
 
-	// MethodShowDocument method name of "window/showDocument".
-	MethodShowDocument = "window/showDocument"
-
 	// MethodWillCreateFiles method name of "workspace/willCreateFiles".
 	MethodWillCreateFiles = "workspace/willCreateFiles"
 
@@ -990,9 +1166,6 @@ const (
 	// MethodDidDeleteFiles method name of "workspace/didDeleteFiles".
 	MethodDidDeleteFiles = "workspace/didDeleteFiles"
 
-	// MethodCodeLensRefresh method name of "workspace/codeLens/refresh".
-	MethodCodeLensRefresh = "workspace/codeLens/refresh"
-
 	// MethodTextDocumentPrepareCallHierarchy method name of "textDocument/prepareCallHierarchy".
 	MethodTextDocumentPrepareCallHierarchy = "textDocument/prepareCallHierarchy"
 
@@ -1011,14 +1184,47 @@ const (
 	// MethodSemanticTokensRange method name of "textDocument/semanticTokens/range".
 	MethodSemanticTokensRange = "textDocument/semanticTokens/range"
 
-	// MethodSemanticTokensRefresh method name of "workspace/semanticTokens/refresh".
-	MethodSemanticTokensRefresh = "workspace/semanticTokens/refresh"
-
 	// MethodLinkedEditingRange method name of "textDocument/linkedEditingRange".
 	MethodLinkedEditingRange = "textDocument/linkedEditingRange"
 
 	// MethodMoniker method name of "textDocument/moniker".
 	MethodMoniker = "textDocument/moniker"
+
+	// MethodTextDocumentInlayHint method name of "textDocument/inlayHint".
+	MethodTextDocumentInlayHint = "textDocument/inlayHint"
+
+	// MethodInlayHintResolve method name of "inlayHint/resolve".
+	MethodInlayHintResolve = "inlayHint/resolve"
+
+	// MethodTextDocumentDiagnostic method name of "textDocument/diagnostic".
+	MethodTextDocumentDiagnostic = "textDocument/diagnostic"
+
+	// MethodWorkspaceDiagnostic method name of "workspace/diagnostic".
+	MethodWorkspaceDiagnostic = "workspace/diagnostic"
+
+	// MethodTextDocumentPrepareTypeHierarchy method name of "textDocument/prepareTypeHierarchy".
+	MethodTextDocumentPrepareTypeHierarchy = "textDocument/prepareTypeHierarchy"
+
+	// MethodTypeHierarchySupertypes method name of "typeHierarchy/supertypes".
+	MethodTypeHierarchySupertypes = "typeHierarchy/supertypes"
+
+	// MethodTypeHierarchySubtypes method name of "typeHierarchy/subtypes".
+	MethodTypeHierarchySubtypes = "typeHierarchy/subtypes"
+
+	// MethodNotebookDocumentDidOpen method name of "notebookDocument/didOpen".
+	MethodNotebookDocumentDidOpen = "notebookDocument/didOpen"
+
+	// MethodNotebookDocumentDidChange method name of "notebookDocument/didChange".
+	MethodNotebookDocumentDidChange = "notebookDocument/didChange"
+
+	// MethodNotebookDocumentDidSave method name of "notebookDocument/didSave".
+	MethodNotebookDocumentDidSave = "notebookDocument/didSave"
+
+	// MethodNotebookDocumentDidClose method name of "notebookDocument/didClose".
+	MethodNotebookDocumentDidClose = "notebookDocument/didClose"
+
+	// MethodTextDocumentInlineValue method name of "textDocument/inlineValue".
+	MethodTextDocumentInlineValue = "textDocument/inlineValue"
 )
 
 // server implements a Language Server Protocol server.
@@ -1144,6 +1350,24 @@ func (s *server) CodeAction(ctx context.Context, params *CodeActionParams) (resu
 	return result, nil
 }
 
+// CodeActionResolve sends the request from the client to the server to resolve additional information for a given code action.
+//
+// This is usually used to compute the edit property of a code action to avoid its unnecessary computation
+// during the "textDocument/codeAction" request.
+//
+// @since 3.16.0.
+func (s *server) CodeActionResolve(ctx context.Context, params *CodeAction) (_ *CodeAction, err error) {
+	s.logger.Debug("call " + MethodCodeActionResolve)
+	defer s.logger.Debug("end "+MethodCodeActionResolve, zap.Error(err))
+
+	var result *CodeAction
+	if err := Call(ctx, s.Conn, MethodCodeActionResolve, params, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // CodeLens sends the request from the client to the server to compute code lenses for a given text document.
 func (s *server) CodeLens(ctx context.Context, params *CodeLensParams) (result []CodeLens, err error) {
 	s.logger.Debug("call " + MethodTextDocumentCodeLens)
@@ -1229,10 +1453,11 @@ func (s *server) CompletionResolve(ctx context.Context, params *CompletionItem)
 // The result type LocationLink[] got introduce with version 3.14.0 and depends in the corresponding client capability `clientCapabilities.textDocument.declaration.linkSupport`.
 //
 // @since 3.14.0.
-func (s *server) Declaration(ctx context.Context, params *DeclarationParams) (result []Location, err error) {
+func (s *server) Declaration(ctx context.Context, params *DeclarationParams) (_ *LocationResult, err error) {
 	s.logger.Debug("call " + MethodTextDocumentDeclaration)
 	defer s.logger.Debug("end "+MethodTextDocumentDeclaration, zap.Error(err))
 
+	var result *LocationResult
 	if err := Call(ctx, s.Conn, MethodTextDocumentDeclaration, params, &result); err != nil {
 		return nil, err
 	}
@@ -1245,10 +1470,11 @@ func (s *server) Declaration(ctx context.Context, params *DeclarationParams) (re
 // The result type `[]LocationLink` got introduce with version 3.14.0 and depends in the corresponding client capability `clientCapabilities.textDocument.definition.linkSupport`.
 //
 // @since 3.14.0.
-func (s *server) Definition(ctx context.Context, params *DefinitionParams) (result []Location, err error) {
+func (s *server) Definition(ctx context.Context, params *DefinitionParams) (_ *LocationResult, err error) {
 	s.logger.Debug("call " + MethodTextDocumentDefinition)
 	defer s.logger.Debug("end "+MethodTextDocumentDefinition, zap.Error(err))
 
+	var result *LocationResult
 	if err := Call(ctx, s.Conn, MethodTextDocumentDefinition, params, &result); err != nil {
 		return nil, err
 	}
@@ -1402,10 +1628,11 @@ func (s *server) DocumentLinkResolve(ctx context.Context, params *DocumentLink)
 // DocumentSymbol sends the request from the client to the server to return a flat list of all symbols found in a given text document.
 //
 // Neither the symbol’s location range nor the symbol’s container name should be used to infer a hierarchy.
-func (s *server) DocumentSymbol(ctx context.Context, params *DocumentSymbolParams) (result []interface{}, err error) {
+func (s *server) DocumentSymbol(ctx context.Context, params *DocumentSymbolParams) (_ *DocumentSymbolResult, err error) {
 	s.logger.Debug("call " + MethodTextDocumentDocumentSymbol)
 	defer s.logger.Debug("end "+MethodTextDocumentDocumentSymbol, zap.Error(err))
 
+	var result *DocumentSymbolResult
 	if err := Call(ctx, s.Conn, MethodTextDocumentDocumentSymbol, params, &result); err != nil {
 		return nil, err
 	}
@@ -1442,6 +1669,22 @@ func (s *server) FoldingRanges(ctx context.Context, params *FoldingRangeParams)
 	return result, nil
 }
 
+// SelectionRange sends the request from the client to the server to return suggested selection ranges at an array of given positions.
+//
+// A selection range is a range around the cursor position which the user might be interested in selecting.
+//
+// @since 3.15.0.
+func (s *server) SelectionRange(ctx context.Context, params *SelectionRangeParams) (result []SelectionRange, err error) {
+	s.logger.Debug("call " + MethodTextDocumentSelectionRange)
+	defer s.logger.Debug("end "+MethodTextDocumentSelectionRange, zap.Error(err))
+
+	if err := Call(ctx, s.Conn, MethodTextDocumentSelectionRange, params, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // Formatting sends the request from the client to the server to format a whole document.
 func (s *server) Formatting(ctx context.Context, params *DocumentFormattingParams) (result []TextEdit, err error) {
 	s.logger.Debug("call " + MethodTextDocumentFormatting)
@@ -1470,10 +1713,11 @@ func (s *server) Hover(ctx context.Context, params *HoverParams) (_ *Hover, err
 // Implementation sends the request from the client to the server to resolve the implementation location of a symbol at a given text document position.
 //
 // The result type `[]LocationLink` got introduce with version 3.14.0 and depends in the corresponding client capability `clientCapabilities.implementation.typeDefinition.linkSupport`.
-func (s *server) Implementation(ctx context.Context, params *ImplementationParams) (result []Location, err error) {
+func (s *server) Implementation(ctx context.Context, params *ImplementationParams) (_ *LocationResult, err error) {
 	s.logger.Debug("call " + MethodTextDocumentImplementation)
 	defer s.logger.Debug("end "+MethodTextDocumentImplementation, zap.Error(err))
 
+	var result *LocationResult
 	if err := Call(ctx, s.Conn, MethodTextDocumentImplementation, params, &result); err != nil {
 		return nil, err
 	}
@@ -1557,10 +1801,13 @@ func (s *server) SignatureHelp(ctx context.Context, params *SignatureHelpParams)
 }
 
 // Symbols sends the request from the client to the server to list project-wide symbols matching the query string.
-func (s *server) Symbols(ctx context.Context, params *WorkspaceSymbolParams) (result []SymbolInformation, err error) {
+//
+// Since 3.17.0 the result can also be a WorkspaceSymbol array, whose locations may omit the range.
+func (s *server) Symbols(ctx context.Context, params *WorkspaceSymbolParams) (_ *WorkspaceSymbolResult, err error) {
 	s.logger.Debug("call " + MethodWorkspaceSymbol)
 	defer s.logger.Debug("end "+MethodWorkspaceSymbol, zap.Error(err))
 
+	var result *WorkspaceSymbolResult
 	if err := Call(ctx, s.Conn, MethodWorkspaceSymbol, params, &result); err != nil {
 		return nil, err
 	}
@@ -1568,15 +1815,31 @@ func (s *server) Symbols(ctx context.Context, params *WorkspaceSymbolParams) (re
 	return result, nil
 }
 
+// WorkspaceSymbolResolve sends the request from the client to the server to resolve additional information for a given workspace symbol.
+//
+// @since 3.17.0.
+func (s *server) WorkspaceSymbolResolve(ctx context.Context, params *WorkspaceSymbol) (_ *WorkspaceSymbol, err error) {
+	s.logger.Debug("call " + MethodWorkspaceSymbolResolve)
+	defer s.logger.Debug("end "+MethodWorkspaceSymbolResolve, zap.Error(err))
+
+	var result *WorkspaceSymbol
+	if err := Call(ctx, s.Conn, MethodWorkspaceSymbolResolve, params, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // TypeDefinition sends the request from the client to the server to resolve the type definition location of a symbol at a given text document position.
 //
 // The result type `[]LocationLink` got introduce with version 3.14.0 and depends in the corresponding client capability `clientCapabilities.textDocument.typeDefinition.linkSupport`.
 //
 // @since version 3.6.0.
-func (s *server) TypeDefinition(ctx context.Context, params *TypeDefinitionParams) (result []Location, err error) {
+func (s *server) TypeDefinition(ctx context.Context, params *TypeDefinitionParams) (_ *LocationResult, err error) {
 	s.logger.Debug("call " + MethodTextDocumentTypeDefinition)
 	defer s.logger.Debug("end "+MethodTextDocumentTypeDefinition, zap.Error(err))
 
+	var result *LocationResult
 	if err := Call(ctx, s.Conn, MethodTextDocumentTypeDefinition, params, &result); err != nil {
 		return nil, err
 	}
@@ -1608,18 +1871,11 @@ func (s *server) WillSaveWaitUntil(ctx context.Context, params *WillSaveTextDocu
 	return result, nil
 }
 
-// ShowDocument sends the request from a server to a client to ask the client to display a particular document in the user interface.
+// ShowDocument forwards the "window/showDocument" request to the client.
 //
-// @since 3.16.0.
-func (s *server) ShowDocument(ctx context.Context, params *ShowDocumentParams) (result *ShowDocumentResult, err error) {
-	s.logger.Debug("call " + MethodShowDocument)
-	defer s.logger.Debug("end "+MethodShowDocument, zap.Error(err))
-
-	if err := Call(ctx, s.Conn, MethodShowDocument, params, &result); err != nil {
-		return nil, err
-	}
-
-	return result, nil
+// Deprecated: "window/showDocument" is a server to client request. Use Client.ShowDocument instead.
+func (s *server) ShowDocument(ctx context.Context, params *ShowDocumentParams) (*ShowDocumentResult, error) {
+	return ClientDispatcher(s.Conn, s.logger).ShowDocument(ctx, params)
 }
 
 // WillCreateFiles sends the will create files request is sent from the client to the server before files are actually created as long as the creation is triggered from within the client.
@@ -1706,20 +1962,11 @@ func (s *server) DidDeleteFiles(ctx context.Context, params *DeleteFilesParams)
 	return s.Conn.Notify(ctx, MethodDidDeleteFiles, params)
 }
 
-// CodeLensRefresh sent from the server to the client.
+// CodeLensRefresh forwards the "workspace/codeLens/refresh" request to the client.
 //
-// Servers can use it to ask clients to refresh the code lenses currently shown in editors.
-// As a result the client should ask the server to recompute the code lenses for these editors.
-// This is useful if a server detects a configuration change which requires a re-calculation of all code lenses.
-//
-// Note that the client still has the freedom to delay the re-calculation of the code lenses if for example an editor is currently not visible.
-//
-// @since 3.16.0.
-func (s *server) CodeLensRefresh(ctx context.Context) (err error) {
-	s.logger.Debug("call " + MethodCodeLensRefresh)
-	defer s.logger.Debug("end "+MethodCodeLensRefresh, zap.Error(err))
-
-	return Call(ctx, s.Conn, MethodCodeLensRefresh, nil, nil)
+// Deprecated: "workspace/codeLens/refresh" is a server to client request. Use Client.CodeLensRefresh instead.
+func (s *server) CodeLensRefresh(ctx context.Context) error {
+	return ClientDispatcher(s.Conn, s.logger).CodeLensRefresh(ctx)
 }
 
 // PrepareCallHierarchy sent from the client to the server to return a call hierarchy for the language element of given text document positions.
@@ -1827,19 +2074,11 @@ func (s *server) SemanticTokensRange(ctx context.Context, params *SemanticTokens
 	return result, nil
 }
 
-// SemanticTokensRefresh is sent from the server to the client. Servers can use it to ask clients to refresh the editors for which this server provides semantic tokens.
+// SemanticTokensRefresh forwards the "workspace/semanticTokens/refresh" request to the client.
 //
-// As a result the client should ask the server to recompute the semantic tokens for these editors.
-// This is useful if a server detects a project wide configuration change which requires a re-calculation of all semantic tokens.
-//
-// Note that the client still has the freedom to delay the re-calculation of the semantic tokens if for example an editor is currently not visible.
-//
-// @since 3.16.0.
-func (s *server) SemanticTokensRefresh(ctx context.Context) (err error) {
-	s.logger.Debug("call " + MethodSemanticTokensRefresh)
-	defer s.logger.Debug("end "+MethodSemanticTokensRefresh, zap.Error(err))
-
-	return Call(ctx, s.Conn, MethodSemanticTokensRefresh, nil, nil)
+// Deprecated: "workspace/semanticTokens/refresh" is a server to client request. Use Client.SemanticTokensRefresh instead.
+func (s *server) SemanticTokensRefresh(ctx context.Context) error {
+	return ClientDispatcher(s.Conn, s.logger).SemanticTokensRefresh(ctx)
 }
 
 // LinkedEditingRange is the linked editing request is sent from the client to the server to return for a given position in a document the range of the symbol at the position and all ranges that have the same content.
@@ -1878,6 +2117,185 @@ func (s *server) Moniker(ctx context.Context, params *MonikerParams) (result []M
 	return result, nil
 }
 
+// InlayHint is the inlay hints request is sent from the client to the server to compute inlay hints for a given [text document, range] tuple
+// that may be rendered in the editor in place with other text.
+//
+// @since 3.17.0.
+func (s *server) InlayHint(ctx context.Context, params *InlayHintParams) (result []InlayHint, err error) {
+	s.logger.Debug("call " + MethodTextDocumentInlayHint)
+	defer s.logger.Debug("end "+MethodTextDocumentInlayHint, zap.Error(err))
+
+	if err := Call(ctx, s.Conn, MethodTextDocumentInlayHint, params, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// InlayHintResolve is the request is sent from the client to the server to resolve additional information for a given inlay hint.
+//
+// This is usually used to compute the tooltip, location or command properties of an inlay hint's label part
+// to avoid its unnecessary computation during the "textDocument/inlayHint" request.
+//
+// @since 3.17.0.
+func (s *server) InlayHintResolve(ctx context.Context, params *InlayHint) (_ *InlayHint, err error) {
+	s.logger.Debug("call " + MethodInlayHintResolve)
+	defer s.logger.Debug("end "+MethodInlayHintResolve, zap.Error(err))
+
+	var result *InlayHint
+	if err := Call(ctx, s.Conn, MethodInlayHintResolve, params, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Diagnostic is the document diagnostic request is sent from the client to the server to ask the server to
+// compute the diagnostics for a given document.
+//
+// As with other pull requests the server is asked to compute the diagnostics for the currently synced version of the document.
+//
+// @since 3.17.0.
+func (s *server) Diagnostic(ctx context.Context, params *DocumentDiagnosticParams) (_ *DocumentDiagnosticReport, err error) {
+	s.logger.Debug("call " + MethodTextDocumentDiagnostic)
+	defer s.logger.Debug("end "+MethodTextDocumentDiagnostic, zap.Error(err))
+
+	var result *DocumentDiagnosticReport
+	if err := Call(ctx, s.Conn, MethodTextDocumentDiagnostic, params, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// WorkspaceDiagnostic is the workspace diagnostic request is sent from the client to the server to ask the server to
+// compute workspace wide diagnostics which previously where pushed from the server to the client.
+//
+// In contrast to the document diagnostic request the workspace request can be long running and is not bound to
+// a specific workspace or document state.
+//
+// @since 3.17.0.
+func (s *server) WorkspaceDiagnostic(ctx context.Context, params *WorkspaceDiagnosticParams) (_ *WorkspaceDiagnosticReport, err error) {
+	s.logger.Debug("call " + MethodWorkspaceDiagnostic)
+	defer s.logger.Debug("end "+MethodWorkspaceDiagnostic, zap.Error(err))
+
+	var result *WorkspaceDiagnosticReport
+	if err := Call(ctx, s.Conn, MethodWorkspaceDiagnostic, params, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// PrepareTypeHierarchy sent from the client to the server to return a type hierarchy for the language element of given text document positions.
+//
+// The type hierarchy requests are executed in two steps:
+//  1. first a type hierarchy item is prepared for the given text document position
+//  2. for a type hierarchy item the supertype or subtype type hierarchy items are resolved.
+//
+// @since 3.17.0.
+func (s *server) PrepareTypeHierarchy(ctx context.Context, params *TypeHierarchyPrepareParams) (result []TypeHierarchyItem, err error) {
+	s.logger.Debug("call " + MethodTextDocumentPrepareTypeHierarchy)
+	defer s.logger.Debug("end "+MethodTextDocumentPrepareTypeHierarchy, zap.Error(err))
+
+	if err := Call(ctx, s.Conn, MethodTextDocumentPrepareTypeHierarchy, params, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Supertypes is the request is sent from the client to the server to resolve the supertypes for a given type hierarchy item.
+//
+// @since 3.17.0.
+func (s *server) Supertypes(ctx context.Context, params *TypeHierarchySupertypesParams) (result []TypeHierarchyItem, err error) {
+	s.logger.Debug("call " + MethodTypeHierarchySupertypes)
+	defer s.logger.Debug("end "+MethodTypeHierarchySupertypes, zap.Error(err))
+
+	if err := Call(ctx, s.Conn, MethodTypeHierarchySupertypes, params, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Subtypes is the request is sent from the client to the server to resolve the subtypes for a given type hierarchy item.
+//
+// @since 3.17.0.
+func (s *server) Subtypes(ctx context.Context, params *TypeHierarchySubtypesParams) (result []TypeHierarchyItem, err error) {
+	s.logger.Debug("call " + MethodTypeHierarchySubtypes)
+	defer s.logger.Debug("end "+MethodTypeHierarchySubtypes, zap.Error(err))
+
+	if err := Call(ctx, s.Conn, MethodTypeHierarchySubtypes, params, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DidOpenNotebookDocument sends the open notification from the client to the server when a notebook document is opened.
+//
+// It is only sent by a client if the server requested the synchronization mode "notebook" in its notebookDocumentSync capability.
+//
+// @since 3.17.0.
+func (s *server) DidOpenNotebookDocument(ctx context.Context, params *DidOpenNotebookDocumentParams) (err error) {
+	s.logger.Debug("notify " + MethodNotebookDocumentDidOpen)
+	defer s.logger.Debug("end "+MethodNotebookDocumentDidOpen, zap.Error(err))
+
+	return s.Conn.Notify(ctx, MethodNotebookDocumentDidOpen, params)
+}
+
+// DidChangeNotebookDocument sends the change notification from the client to the server when a notebook document changes.
+//
+// It is only sent by a client if the server requested the synchronization mode "notebook" in its notebookDocumentSync capability.
+//
+// @since 3.17.0.
+func (s *server) DidChangeNotebookDocument(ctx context.Context, params *DidChangeNotebookDocumentParams) (err error) {
+	s.logger.Debug("notify " + MethodNotebookDocumentDidChange)
+	defer s.logger.Debug("end "+MethodNotebookDocumentDidChange, zap.Error(err))
+
+	return s.Conn.Notify(ctx, MethodNotebookDocumentDidChange, params)
+}
+
+// DidSaveNotebookDocument sends the save notification from the client to the server when a notebook document is saved.
+//
+// It is only sent by a client if the server requested the synchronization mode "notebook" in its notebookDocumentSync capability.
+//
+// @since 3.17.0.
+func (s *server) DidSaveNotebookDocument(ctx context.Context, params *DidSaveNotebookDocumentParams) (err error) {
+	s.logger.Debug("notify " + MethodNotebookDocumentDidSave)
+	defer s.logger.Debug("end "+MethodNotebookDocumentDidSave, zap.Error(err))
+
+	return s.Conn.Notify(ctx, MethodNotebookDocumentDidSave, params)
+}
+
+// DidCloseNotebookDocument sends the close notification from the client to the server when a notebook document is closed.
+//
+// It is only sent by a client if the server requested the synchronization mode "notebook" in its notebookDocumentSync capability.
+//
+// @since 3.17.0.
+func (s *server) DidCloseNotebookDocument(ctx context.Context, params *DidCloseNotebookDocumentParams) (err error) {
+	s.logger.Debug("notify " + MethodNotebookDocumentDidClose)
+	defer s.logger.Debug("end "+MethodNotebookDocumentDidClose, zap.Error(err))
+
+	return s.Conn.Notify(ctx, MethodNotebookDocumentDidClose, params)
+}
+
+// InlineValue is the inline value request is sent from the client to the server to compute inline values for a given text document
+// that may be rendered in the editor at the end of lines.
+//
+// @since 3.17.0.
+func (s *server) InlineValue(ctx context.Context, params *InlineValueParams) (result []InlineValue, err error) {
+	s.logger.Debug("call " + MethodTextDocumentInlineValue)
+	defer s.logger.Debug("end "+MethodTextDocumentInlineValue, zap.Error(err))
+
+	if err := Call(ctx, s.Conn, MethodTextDocumentInlineValue, params, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // Request sends a request from the client to the server that non-compliant with the Language Server Protocol specifications.
 func (s *server) Request(ctx context.Context, method string, params interface{}) (interface{}, error) {
 	s.logger.Debug("call " + method)
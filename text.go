@@ -4,6 +4,7 @@
 package protocol
 
 import (
+	"fmt"
 	"strconv"
 )
 
@@ -87,15 +88,68 @@ type DidSaveTextDocumentParams struct {
 // the new text is considered to be the full content of the document.
 type TextDocumentContentChangeEvent struct {
 	// Range is the range of the document that changed.
-	Range Range `json:"range"`
+	//
+	// Omitted when Text is the full content of the document.
+	Range *Range `json:"range,omitempty"`
 
 	// RangeLength is the length of the range that got replaced.
+	//
+	// Deprecated: Use Range instead.
 	RangeLength uint32 `json:"rangeLength,omitempty"`
 
 	// Text is the new text of the document.
 	Text string `json:"text"`
 }
 
+// IsFull reports whether the event replaces the full content of the document.
+func (e TextDocumentContentChangeEvent) IsFull() bool {
+	return e.Range == nil
+}
+
+// IsIncremental reports whether the event replaces a range of the document.
+func (e TextDocumentContentChangeEvent) IsIncremental() bool {
+	return e.Range != nil
+}
+
+// SyncKind returns TextDocumentSyncKindFull for a full content change and
+// TextDocumentSyncKindIncremental for a range change.
+func (e TextDocumentContentChangeEvent) SyncKind() TextDocumentSyncKind {
+	if e.IsFull() {
+		return TextDocumentSyncKindFull
+	}
+
+	return TextDocumentSyncKindIncremental
+}
+
+// ValidateRangeLength reports whether RangeLength agrees with Range when applied to text,
+// the content of the document before the change.
+//
+// RangeLength counts UTF-16 code units. A zero RangeLength is treated as omitted,
+// and a full content change must not carry one. Positions past the end of a line or
+// of text are clamped, as described on Position.
+func (e TextDocumentContentChangeEvent) ValidateRangeLength(text string) error {
+	if e.Range == nil {
+		if e.RangeLength != 0 {
+			return fmt.Errorf("rangeLength %d set on a full content change", e.RangeLength)
+		}
+		return nil
+	}
+	if e.RangeLength == 0 {
+		return nil
+	}
+
+	if comparePosition(e.Range.Start, e.Range.End) > 0 {
+		return fmt.Errorf("range start %d:%d is after end %d:%d", e.Range.Start.Line, e.Range.Start.Character, e.Range.End.Line, e.Range.End.Character)
+	}
+	index := NewLineIndex(text)
+	start, end := index.UTF16Offset(e.Range.Start), index.UTF16Offset(e.Range.End)
+	if n := uint32(end - start); n != e.RangeLength {
+		return fmt.Errorf("rangeLength %d does not match range length %d", e.RangeLength, n)
+	}
+
+	return nil
+}
+
 // TextDocumentSaveRegistrationOptions TextDocumentSave Registration options.
 type TextDocumentSaveRegistrationOptions struct {
 	TextDocumentRegistrationOptions
@@ -125,7 +125,7 @@ func TestDidChangeTextDocumentParams(t *testing.T) {
 		},
 		ContentChanges: []TextDocumentContentChangeEvent{
 			{
-				Range: Range{
+				Range: &Range{
 					Start: Position{
 						Line:      25,
 						Character: 1,
@@ -271,7 +271,7 @@ func TestTextDocumentContentChangeEvent(t *testing.T) {
 		wantInvalid = `{"range":{"start":{"line":2,"character":1},"end":{"line":3,"character":4}},"rangeLength":3,"text":"invalidText"}`
 	)
 	wantType := TextDocumentContentChangeEvent{
-		Range: Range{
+		Range: &Range{
 			Start: Position{
 				Line:      25,
 				Character: 1,
@@ -366,6 +366,163 @@ func TestTextDocumentContentChangeEvent(t *testing.T) {
 	})
 }
 
+func TestTextDocumentContentChangeEvent_SyncKind(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		payload  string
+		want     TextDocumentSyncKind
+		wantFull bool
+	}{
+		{
+			name:     "Full",
+			payload:  `{"text":"package main\n"}`,
+			want:     TextDocumentSyncKindFull,
+			wantFull: true,
+		},
+		{
+			name:     "Incremental",
+			payload:  `{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":0}},"text":"// comment\n"}`,
+			want:     TextDocumentSyncKindIncremental,
+			wantFull: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got TextDocumentContentChangeEvent
+			if err := json.Unmarshal([]byte(tt.payload), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if got.SyncKind() != tt.want {
+				t.Errorf("SyncKind() = %v, want %v", got.SyncKind(), tt.want)
+			}
+			if got.IsFull() != tt.wantFull {
+				t.Errorf("IsFull() = %t, want %t", got.IsFull(), tt.wantFull)
+			}
+			if got.IsIncremental() == tt.wantFull {
+				t.Errorf("IsIncremental() = %t, want %t", got.IsIncremental(), !tt.wantFull)
+			}
+
+			data, err := json.Marshal(&got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.payload, string(data)); diff != "" {
+				t.Errorf("(-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTextDocumentContentChangeEvent_ValidateRangeLength(t *testing.T) {
+	t.Parallel()
+
+	const text = "package main\r\n\nfunc 𝔣() {}\rvar x"
+
+	tests := []struct {
+		name    string
+		event   TextDocumentContentChangeEvent
+		wantErr bool
+	}{
+		{
+			name:    "Full",
+			event:   TextDocumentContentChangeEvent{Text: "package foo"},
+			wantErr: false,
+		},
+		{
+			name:    "FullWithRangeLength",
+			event:   TextDocumentContentChangeEvent{RangeLength: 3, Text: "package foo"},
+			wantErr: true,
+		},
+		{
+			name: "OmittedRangeLength",
+			event: TextDocumentContentChangeEvent{
+				Range: &Range{Start: Position{Line: 0, Character: 8}, End: Position{Line: 0, Character: 12}},
+				Text:  "foo",
+			},
+			wantErr: false,
+		},
+		{
+			name: "SingleLine",
+			event: TextDocumentContentChangeEvent{
+				Range:       &Range{Start: Position{Line: 0, Character: 8}, End: Position{Line: 0, Character: 12}},
+				RangeLength: 4,
+				Text:        "foo",
+			},
+			wantErr: false,
+		},
+		{
+			name: "MultiLine",
+			event: TextDocumentContentChangeEvent{
+				Range:       &Range{Start: Position{Line: 0, Character: 8}, End: Position{Line: 3, Character: 3}},
+				RangeLength: 23,
+				Text:        "",
+			},
+			wantErr: false,
+		},
+		{
+			name: "SurrogatePair",
+			event: TextDocumentContentChangeEvent{
+				Range:       &Range{Start: Position{Line: 2, Character: 5}, End: Position{Line: 2, Character: 7}},
+				RangeLength: 2,
+				Text:        "g",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Mismatch",
+			event: TextDocumentContentChangeEvent{
+				Range:       &Range{Start: Position{Line: 0, Character: 8}, End: Position{Line: 0, Character: 12}},
+				RangeLength: 3,
+				Text:        "foo",
+			},
+			wantErr: true,
+		},
+		{
+			name: "CharacterPastEndOfLine",
+			event: TextDocumentContentChangeEvent{
+				Range:       &Range{Start: Position{Line: 0, Character: 8}, End: Position{Line: 0, Character: 20}},
+				RangeLength: 4,
+				Text:        "",
+			},
+			wantErr: false,
+		},
+		{
+			name: "LinePastEndOfText",
+			event: TextDocumentContentChangeEvent{
+				Range:       &Range{Start: Position{Line: 3, Character: 0}, End: Position{Line: 5, Character: 0}},
+				RangeLength: 5,
+				Text:        "",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Reversed",
+			event: TextDocumentContentChangeEvent{
+				Range:       &Range{Start: Position{Line: 0, Character: 12}, End: Position{Line: 0, Character: 8}},
+				RangeLength: 4,
+				Text:        "",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.event.ValidateRangeLength(text); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRangeLength() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestTextDocumentChangeRegistrationOptions(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ApplyTextEdits applies edits to text and returns the new text together with the edits that undo them.
+//
+// The Character of every Position counts code units of enc; an empty enc counts UTF-16 code units.
+// Edits are applied in the order of their start positions, an insert before an edit replacing the text that
+// starts at the same position. Inserts at the same position are applied in the order they appear in edits, as the
+// specification requires.
+//
+// An edit whose range ends before it starts, or that overlaps another edit, is reported as an error and text
+// is left untouched.
+//
+// The inverse edits are sorted by position and refer to the new text; applying them to it restores text.
+func ApplyTextEdits(text string, edits []TextEdit, enc PositionEncodingKind) (string, []TextEdit, error) {
+	type span struct {
+		index      int
+		start, end int
+	}
+
+	index := NewLineIndex(text)
+	spans := make([]span, len(edits))
+	for i, edit := range edits {
+		start, end := index.Offset(edit.Range.Start, enc), index.Offset(edit.Range.End, enc)
+		if start > end {
+			return "", nil, fmt.Errorf("edits[%d]: range %s ends before it starts", i, formatRange(edit.Range))
+		}
+		spans[i] = span{index: i, start: start, end: end}
+	}
+	sort.SliceStable(spans, func(i, j int) bool {
+		if spans[i].start != spans[j].start {
+			return spans[i].start < spans[j].start
+		}
+		// an insert goes before an edit replacing the text that follows it.
+		return spans[i].end < spans[j].end
+	})
+
+	var b strings.Builder
+	b.Grow(len(text))
+	inserted := make([]span, len(spans))
+	last := 0
+	for i, s := range spans {
+		if s.start < last {
+			prev := spans[i-1].index
+			return "", nil, fmt.Errorf("edits[%d]: range %s overlaps edits[%d] range %s",
+				s.index, formatRange(edits[s.index].Range), prev, formatRange(edits[prev].Range))
+		}
+		b.WriteString(text[last:s.start])
+		inserted[i].start = b.Len()
+		b.WriteString(edits[s.index].NewText)
+		inserted[i].end = b.Len()
+		last = s.end
+	}
+	b.WriteString(text[last:])
+	result := b.String()
+
+	resultIndex := NewLineIndex(result)
+	inverse := make([]TextEdit, len(spans))
+	for i, s := range spans {
+		inverse[i] = TextEdit{
+			Range: Range{
+				Start: resultIndex.Position(inserted[i].start, enc),
+				End:   resultIndex.Position(inserted[i].end, enc),
+			},
+			NewText: text[s.start:s.end],
+		}
+	}
+
+	return result, inverse, nil
+}
+
+// formatRange formats rng as "line:character-line:character".
+func formatRange(rng Range) string {
+	return fmt.Sprintf("%d:%d-%d:%d", rng.Start.Line, rng.Start.Character, rng.End.Line, rng.End.Character)
+}
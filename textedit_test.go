@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTextEdit(startLine, startChar, endLine, endChar uint32, newText string) TextEdit {
+	return TextEdit{
+		Range: Range{
+			Start: Position{Line: startLine, Character: startChar},
+			End:   Position{Line: endLine, Character: endChar},
+		},
+		NewText: newText,
+	}
+}
+
+func TestApplyTextEdits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		text    string
+		edits   []TextEdit
+		enc     PositionEncodingKind
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "NoEdits",
+			text:  "package main\n",
+			edits: nil,
+			want:  "package main\n",
+		},
+		{
+			name: "Unsorted",
+			text: "package main\n\nfunc main() {}\n",
+			edits: []TextEdit{
+				newTextEdit(2, 5, 2, 9, "run"),
+				newTextEdit(0, 8, 0, 12, "foo"),
+			},
+			want: "package foo\n\nfunc run() {}\n",
+		},
+		{
+			name: "InsertInsideReplace",
+			text: "ac",
+			edits: []TextEdit{
+				newTextEdit(0, 1, 0, 1, "b"),
+				newTextEdit(0, 0, 0, 2, ""),
+				newTextEdit(0, 2, 0, 2, "1"),
+				newTextEdit(0, 2, 0, 2, "2"),
+				newTextEdit(0, 1, 0, 1, "B"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "OrderedInserts",
+			text: "ac",
+			edits: []TextEdit{
+				newTextEdit(0, 2, 0, 2, "1"),
+				newTextEdit(0, 1, 0, 1, "b"),
+				newTextEdit(0, 2, 0, 2, "2"),
+				newTextEdit(0, 1, 0, 1, "B"),
+				newTextEdit(0, 0, 0, 0, "_"),
+			},
+			want: "_abBc12",
+		},
+		{
+			name: "InsertAtStartOfReplace",
+			text: "abcdef",
+			edits: []TextEdit{
+				newTextEdit(0, 1, 0, 3, "X"),
+				newTextEdit(0, 1, 0, 1, "I"),
+			},
+			want: "aIXdef",
+		},
+		{
+			name: "InsertAtStartOfReplaceReversed",
+			text: "abcdef",
+			edits: []TextEdit{
+				newTextEdit(0, 1, 0, 1, "I"),
+				newTextEdit(0, 1, 0, 3, "X"),
+			},
+			want: "aIXdef",
+		},
+		{
+			name: "Adjacent",
+			text: "abcd",
+			edits: []TextEdit{
+				newTextEdit(0, 2, 0, 4, "CD"),
+				newTextEdit(0, 0, 0, 2, "AB"),
+			},
+			want: "ABCD",
+		},
+		{
+			name: "MultiLine",
+			text: "a\r\nb\rc\nd",
+			edits: []TextEdit{
+				newTextEdit(0, 1, 2, 0, " "),
+				newTextEdit(3, 0, 3, 1, "D\n"),
+			},
+			want: "a c\nD\n",
+		},
+		{
+			name:  "UTF16",
+			text:  "a𝔣b",
+			edits: []TextEdit{newTextEdit(0, 1, 0, 3, "f")},
+			enc:   PositionEncodingKindUTF16,
+			want:  "afb",
+		},
+		{
+			name:  "UTF8",
+			text:  "a𝔣b",
+			edits: []TextEdit{newTextEdit(0, 1, 0, 5, "f")},
+			enc:   PositionEncodingKindUTF8,
+			want:  "afb",
+		},
+		{
+			name:  "UTF32",
+			text:  "a𝔣b",
+			edits: []TextEdit{newTextEdit(0, 1, 0, 2, "f")},
+			enc:   PositionEncodingKindUTF32,
+			want:  "afb",
+		},
+		{
+			name:  "PastEnd",
+			text:  "ab\ncd",
+			edits: []TextEdit{newTextEdit(1, 1, 5, 0, "!")},
+			want:  "ab\nc!",
+		},
+		{
+			name: "Overlap",
+			text: "abcdef",
+			edits: []TextEdit{
+				newTextEdit(0, 0, 0, 3, "x"),
+				newTextEdit(0, 2, 0, 4, "y"),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Reversed",
+			text:    "abcdef",
+			edits:   []TextEdit{newTextEdit(0, 4, 0, 2, "x")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, inverse, err := ApplyTextEdits(tt.text, tt.edits, tt.enc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyTextEdits() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got)\n%s", diff)
+			}
+
+			undone, _, err := ApplyTextEdits(got, inverse, tt.enc)
+			if err != nil {
+				t.Fatalf("applying inverse edits: %v", err)
+			}
+			if diff := cmp.Diff(tt.text, undone); diff != "" {
+				t.Errorf("inverse edits: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyTextEdits_Error(t *testing.T) {
+	t.Parallel()
+
+	edits := []TextEdit{
+		newTextEdit(0, 4, 0, 6, "y"),
+		newTextEdit(0, 0, 0, 5, "x"),
+	}
+	_, _, err := ApplyTextEdits("abcdef", edits, PositionEncodingKindUTF16)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	const want = "edits[0]: range 0:4-0:6 overlaps edits[1] range 0:0-0:5"
+	if got := err.Error(); got != want {
+		t.Errorf("error = %q, want %q", got, want)
+	}
+}
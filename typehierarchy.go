@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package protocol
+
+// TypeHierarchyPrepareParams params of TypeHierarchyPrepare.
+//
+// @since 3.17.0.
+type TypeHierarchyPrepareParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+}
+
+// TypeHierarchyItem is the result of a "textDocument/prepareTypeHierarchy" request.
+//
+// @since 3.17.0.
+type TypeHierarchyItem struct {
+	// Name is the name of this item.
+	Name string `json:"name"`
+
+	// Kind is the kind of this item.
+	Kind SymbolKind `json:"kind"`
+
+	// Tags for this item.
+	Tags []SymbolTag `json:"tags,omitempty"`
+
+	// Detail more detail for this item, e.g. the signature of a function.
+	Detail string `json:"detail,omitempty"`
+
+	// URI is the resource identifier of this item.
+	URI DocumentURI `json:"uri"`
+
+	// Range is the range enclosing this symbol not including leading/trailing whitespace
+	// but everything else, e.g. comments and code.
+	Range Range `json:"range"`
+
+	// SelectionRange is the range that should be selected and revealed when this symbol is being
+	// picked, e.g. the name of a function. Must be contained by the
+	// Range.
+	SelectionRange Range `json:"selectionRange"`
+
+	// Data is a data entry field that is preserved between a type hierarchy prepare and
+	// supertypes or subtypes requests. It could also be used to identify the
+	// type hierarchy in the server, helping improve the performance on
+	// resolving supertypes and subtypes.
+	Data interface{} `json:"data,omitempty"`
+}
+
+// TypeHierarchySupertypesParams params of TypeHierarchySupertypes.
+//
+// @since 3.17.0.
+type TypeHierarchySupertypesParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// Item is the Supertypes item.
+	Item TypeHierarchyItem `json:"item"`
+}
+
+// TypeHierarchySubtypesParams params of TypeHierarchySubtypes.
+//
+// @since 3.17.0.
+type TypeHierarchySubtypesParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// Item is the Subtypes item.
+	Item TypeHierarchyItem `json:"item"`
+}
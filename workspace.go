@@ -6,6 +6,8 @@ package protocol
 import (
 	"strconv"
 
+	"github.com/segmentio/encoding/json"
+
 	"go.lsp.dev/uri"
 )
 
@@ -163,6 +165,129 @@ type WorkspaceSymbolParams struct {
 	Query string `json:"query"`
 }
 
+// WorkspaceSymbol is a special workspace symbol that supports locations without a range.
+//
+// @since 3.17.0.
+type WorkspaceSymbol struct {
+	// Name is the name of this symbol.
+	Name string `json:"name"`
+
+	// Kind is the kind of this symbol.
+	Kind SymbolKind `json:"kind"`
+
+	// Tags for this completion item.
+	Tags []SymbolTag `json:"tags,omitempty"`
+
+	// ContainerName is the name of the symbol containing this symbol. This information is for
+	// user interface purposes (e.g. to render a qualifier in the user interface
+	// if necessary). It can't be used to re-infer a hierarchy for the document
+	// symbols.
+	ContainerName string `json:"containerName,omitempty"`
+
+	// Location is the location of this symbol. Whether a server is allowed to
+	// return a location without a range depends on the client
+	// capability "workspace.symbol.resolveSupport".
+	Location WorkspaceSymbolLocation `json:"location"`
+
+	// Data is a data entry field that is preserved on a workspace symbol between a
+	// workspace symbol request and a workspace symbol resolve request.
+	Data interface{} `json:"data,omitempty"`
+}
+
+// WorkspaceSymbolLocation is the location of a WorkspaceSymbol.
+//
+// Range is nil if the location only carries the URI, in which case the
+// range is computed by a "workspaceSymbol/resolve" request.
+//
+// @since 3.17.0.
+type WorkspaceSymbolLocation struct {
+	// URI is the resource identifier of this location.
+	URI DocumentURI `json:"uri"`
+
+	// Range is the range of this location, if known.
+	Range *Range `json:"range,omitempty"`
+}
+
+// Location returns the full Location, and reports whether the range is known.
+func (l WorkspaceSymbolLocation) Location() (Location, bool) {
+	if l.Range == nil {
+		return Location{URI: l.URI}, false
+	}
+
+	return Location{URI: l.URI, Range: *l.Range}, true
+}
+
+// WorkspaceSymbolResult is the result of a "workspace/symbol" request.
+//
+// The result is either a SymbolInformation array or, since 3.17.0, a WorkspaceSymbol array.
+// WorkspaceSymbols takes precedence when marshaling.
+//
+// Both shapes look alike on the wire, so an array is decoded as WorkspaceSymbols only
+// if one of its elements has a location without range or carries data, and as
+// SymbolInformation otherwise.
+type WorkspaceSymbolResult struct {
+	SymbolInformation []SymbolInformation
+	WorkspaceSymbols  []WorkspaceSymbol
+}
+
+// compile time check whether the WorkspaceSymbolResult implements a json.Marshaler and json.Unmarshaler interfaces.
+var (
+	_ json.Marshaler   = (*WorkspaceSymbolResult)(nil)
+	_ json.Unmarshaler = (*WorkspaceSymbolResult)(nil)
+)
+
+// Symbols returns the result as WorkspaceSymbols, converting SymbolInformation if needed.
+func (r *WorkspaceSymbolResult) Symbols() []WorkspaceSymbol {
+	if r.WorkspaceSymbols != nil || r.SymbolInformation == nil {
+		return r.WorkspaceSymbols
+	}
+
+	symbols := make([]WorkspaceSymbol, len(r.SymbolInformation))
+	for i, info := range r.SymbolInformation {
+		rng := info.Location.Range
+		symbols[i] = WorkspaceSymbol{
+			Name:          info.Name,
+			Kind:          info.Kind,
+			Tags:          info.Tags,
+			ContainerName: info.ContainerName,
+			Location: WorkspaceSymbolLocation{
+				URI:   info.Location.URI,
+				Range: &rng,
+			},
+		}
+	}
+
+	return symbols
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r WorkspaceSymbolResult) MarshalJSON() ([]byte, error) {
+	if r.WorkspaceSymbols != nil {
+		return json.Marshal(r.WorkspaceSymbols)
+	}
+
+	return json.Marshal(r.SymbolInformation)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *WorkspaceSymbolResult) UnmarshalJSON(data []byte) error {
+	*r = WorkspaceSymbolResult{}
+
+	var symbols []WorkspaceSymbol
+	if err := json.Unmarshal(data, &symbols); err != nil {
+		return err
+	}
+
+	for _, symbol := range symbols {
+		if symbol.Location.Range == nil || symbol.Data != nil {
+			r.WorkspaceSymbols = symbols
+			return nil
+		}
+	}
+
+	return json.Unmarshal(data, &r.SymbolInformation)
+}
+
 // ExecuteCommandParams params of Execute a command.
 type ExecuteCommandParams struct {
 	WorkDoneProgressParams
@@ -208,6 +333,8 @@ type ApplyWorkspaceEditResponse struct {
 	// only available if the client signals a "failureHandlingStrategy"
 	// in its client capabilities.
 	//
+	// A pointer, so that the first change (index 0) is not dropped from the JSON.
+	//
 	// @since 3.16.0.
-	FailedChange uint32 `json:"failedChange,omitempty"`
+	FailedChange *uint32 `json:"failedChange,omitempty"`
 }
@@ -1184,6 +1184,295 @@ func TestWorkspaceSymbolParams(t *testing.T) {
 	})
 }
 
+func TestWorkspaceSymbol(t *testing.T) {
+	t.Parallel()
+
+	const (
+		want        = `{"name":"testName","kind":12,"tags":[1],"containerName":"testContainerName","location":{"uri":"file:///path/to/test.go"},"data":"testData"}`
+		wantRange   = `{"name":"testName","kind":12,"location":{"uri":"file:///path/to/test.go","range":{"start":{"line":25,"character":1},"end":{"line":27,"character":3}}}}`
+		wantInvalid = `{"name":"invalidName","kind":1,"location":{"uri":"file:///path/to/invalid.go"}}`
+	)
+	wantType := WorkspaceSymbol{
+		Name:          "testName",
+		Kind:          SymbolKindFunction,
+		Tags:          []SymbolTag{SymbolTagDeprecated},
+		ContainerName: "testContainerName",
+		Location: WorkspaceSymbolLocation{
+			URI: uri.File("/path/to/test.go"),
+		},
+		Data: "testData",
+	}
+	wantTypeRange := WorkspaceSymbol{
+		Name: "testName",
+		Kind: SymbolKindFunction,
+		Location: WorkspaceSymbolLocation{
+			URI: uri.File("/path/to/test.go"),
+			Range: &Range{
+				Start: Position{Line: 25, Character: 1},
+				End:   Position{Line: 27, Character: 3},
+			},
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          WorkspaceSymbol
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "Valid",
+				field:          wantType,
+				want:           want,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "ValidRange",
+				field:          wantTypeRange,
+				want:           wantRange,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Invalid",
+				field:          wantType,
+				want:           wantInvalid,
+				wantMarshalErr: false,
+				wantErr:        true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             WorkspaceSymbol
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "Valid",
+				field:            want,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "ValidRange",
+				field:            wantRange,
+				want:             wantTypeRange,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Invalid",
+				field:            wantInvalid,
+				want:             wantType,
+				wantUnmarshalErr: false,
+				wantErr:          true,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got WorkspaceSymbol
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+}
+
+func TestWorkspaceSymbolResult(t *testing.T) {
+	t.Parallel()
+
+	const (
+		wantSymbolInformation = `[{"name":"testName","kind":12,"location":{"uri":"file:///path/to/test.go","range":{"start":{"line":25,"character":1},"end":{"line":27,"character":3}}}}]`
+		wantWorkspaceSymbols  = `[{"name":"testName","kind":12,"location":{"uri":"file:///path/to/test.go"}}]`
+		wantNil               = `null`
+	)
+	rng := Range{
+		Start: Position{Line: 25, Character: 1},
+		End:   Position{Line: 27, Character: 3},
+	}
+	wantTypeSymbolInformation := WorkspaceSymbolResult{
+		SymbolInformation: []SymbolInformation{
+			{
+				Name: "testName",
+				Kind: SymbolKindFunction,
+				Location: Location{
+					URI:   uri.File("/path/to/test.go"),
+					Range: rng,
+				},
+			},
+		},
+	}
+	wantTypeWorkspaceSymbols := WorkspaceSymbolResult{
+		WorkspaceSymbols: []WorkspaceSymbol{
+			{
+				Name: "testName",
+				Kind: SymbolKindFunction,
+				Location: WorkspaceSymbolLocation{
+					URI: uri.File("/path/to/test.go"),
+				},
+			},
+		},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name           string
+			field          WorkspaceSymbolResult
+			want           string
+			wantMarshalErr bool
+			wantErr        bool
+		}{
+			{
+				name:           "SymbolInformation",
+				field:          wantTypeSymbolInformation,
+				want:           wantSymbolInformation,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "WorkspaceSymbols",
+				field:          wantTypeWorkspaceSymbols,
+				want:           wantWorkspaceSymbols,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+			{
+				name:           "Nil",
+				field:          WorkspaceSymbolResult{},
+				want:           wantNil,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := json.Marshal(&tt.field)
+				if (err != nil) != tt.wantMarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, string(got)); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			field            string
+			want             WorkspaceSymbolResult
+			wantUnmarshalErr bool
+			wantErr          bool
+		}{
+			{
+				name:             "SymbolInformation",
+				field:            wantSymbolInformation,
+				want:             wantTypeSymbolInformation,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "WorkspaceSymbols",
+				field:            wantWorkspaceSymbols,
+				want:             wantTypeWorkspaceSymbols,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+			{
+				name:             "Nil",
+				field:            wantNil,
+				want:             WorkspaceSymbolResult{},
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got WorkspaceSymbolResult
+				if err := json.Unmarshal([]byte(tt.field), &got); (err != nil) != tt.wantUnmarshalErr {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); (diff != "") != tt.wantErr {
+					t.Errorf("%s: wantErr: %t\n(-want +got)\n%s", tt.name, tt.wantErr, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Symbols", func(t *testing.T) {
+		t.Parallel()
+
+		want := []WorkspaceSymbol{
+			{
+				Name: "testName",
+				Kind: SymbolKindFunction,
+				Location: WorkspaceSymbolLocation{
+					URI:   uri.File("/path/to/test.go"),
+					Range: &rng,
+				},
+			},
+		}
+		if diff := cmp.Diff(want, wantTypeSymbolInformation.Symbols()); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+		if diff := cmp.Diff(wantTypeWorkspaceSymbols.WorkspaceSymbols, wantTypeWorkspaceSymbols.Symbols()); diff != "" {
+			t.Errorf("(-want +got)\n%s", diff)
+		}
+	})
+}
+
 func TestExecuteCommandParams(t *testing.T) {
 	t.Parallel()
 
@@ -1433,27 +1722,31 @@ func TestApplyWorkspaceEditParams(t *testing.T) {
 					},
 				},
 			},
-			DocumentChanges: []TextDocumentEdit{
+			DocumentChanges: []DocumentChange{
 				{
-					TextDocument: OptionalVersionedTextDocumentIdentifier{
-						TextDocumentIdentifier: TextDocumentIdentifier{
-							URI: uri.File("/path/to/basic.go"),
+					TextDocumentEdit: &TextDocumentEdit{
+						TextDocument: OptionalVersionedTextDocumentIdentifier{
+							TextDocumentIdentifier: TextDocumentIdentifier{
+								URI: uri.File("/path/to/basic.go"),
+							},
+							Version: NewVersion(int32(10)),
 						},
-						Version: NewVersion(int32(10)),
-					},
-					Edits: []TextEdit{
-						{
-							Range: Range{
-								Start: Position{
-									Line:      25,
-									Character: 1,
-								},
-								End: Position{
-									Line:      27,
-									Character: 3,
+						Edits: []TextDocumentEditItem{
+							{
+								TextEdit: &TextEdit{
+									Range: Range{
+										Start: Position{
+											Line:      25,
+											Character: 1,
+										},
+										End: Position{
+											Line:      27,
+											Character: 3,
+										},
+									},
+									NewText: "foo bar",
 								},
 							},
-							NewText: "foo bar",
 						},
 					},
 				},
@@ -1479,27 +1772,31 @@ func TestApplyWorkspaceEditParams(t *testing.T) {
 					},
 				},
 			},
-			DocumentChanges: []TextDocumentEdit{
+			DocumentChanges: []DocumentChange{
 				{
-					TextDocument: OptionalVersionedTextDocumentIdentifier{
-						TextDocumentIdentifier: TextDocumentIdentifier{
-							URI: uri.File("/path/to/basic.go"),
+					TextDocumentEdit: &TextDocumentEdit{
+						TextDocument: OptionalVersionedTextDocumentIdentifier{
+							TextDocumentIdentifier: TextDocumentIdentifier{
+								URI: uri.File("/path/to/basic.go"),
+							},
+							Version: NewVersion(int32(10)),
 						},
-						Version: NewVersion(int32(10)),
-					},
-					Edits: []TextEdit{
-						{
-							Range: Range{
-								Start: Position{
-									Line:      25,
-									Character: 1,
-								},
-								End: Position{
-									Line:      27,
-									Character: 3,
+						Edits: []TextDocumentEditItem{
+							{
+								TextEdit: &TextEdit{
+									Range: Range{
+										Start: Position{
+											Line:      25,
+											Character: 1,
+										},
+										End: Position{
+											Line:      27,
+											Character: 3,
+										},
+									},
+									NewText: "foo bar",
 								},
 							},
-							NewText: "foo bar",
 						},
 					},
 				},
@@ -1606,13 +1903,19 @@ func TestApplyWorkspaceEditResponse(t *testing.T) {
 	t.Parallel()
 
 	const (
-		want        = `{"applied":true,"failureReason":"testFailureReason","failedChange":1}`
-		wantInvalid = `{"applied":false}`
+		want            = `{"applied":true,"failureReason":"testFailureReason","failedChange":1}`
+		wantFirstChange = `{"applied":false,"failedChange":0}`
+		wantInvalid     = `{"applied":false}`
 	)
+	failedChange, firstChange := uint32(1), uint32(0)
 	wantType := ApplyWorkspaceEditResponse{
 		Applied:       true,
 		FailureReason: "testFailureReason",
-		FailedChange:  1,
+		FailedChange:  &failedChange,
+	}
+	wantTypeFirstChange := ApplyWorkspaceEditResponse{
+		Applied:      false,
+		FailedChange: &firstChange,
 	}
 
 	t.Run("Marshal", func(t *testing.T) {
@@ -1630,6 +1933,13 @@ func TestApplyWorkspaceEditResponse(t *testing.T) {
 				wantMarshalErr: false,
 				wantErr:        false,
 			},
+			{
+				name:           "ValidFirstChange",
+				field:          wantTypeFirstChange,
+				want:           wantFirstChange,
+				wantMarshalErr: false,
+				wantErr:        false,
+			},
 			{
 				name:           "Invalid",
 				field:          wantType,
@@ -1670,6 +1980,13 @@ func TestApplyWorkspaceEditResponse(t *testing.T) {
 				wantUnmarshalErr: false,
 				wantErr:          false,
 			},
+			{
+				name:             "ValidFirstChange",
+				field:            wantFirstChange,
+				want:             wantTypeFirstChange,
+				wantUnmarshalErr: false,
+				wantErr:          false,
+			},
 			{
 				name:             "Invalid",
 				field:            wantInvalid,
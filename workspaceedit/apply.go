@@ -0,0 +1,346 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package workspaceedit applies WorkspaceEdits to a file system.
+package workspaceedit // import "go.lsp.dev/protocol/workspaceedit"
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"go.lsp.dev/protocol"
+)
+
+// change is a single change of a WorkspaceEdit, executed against fsys.
+type change func(fsys FS) error
+
+// Apply applies edit to fsys and reports the outcome the way a client replies to a workspace/applyEdit request.
+//
+// The DocumentChanges are executed in order and take precedence over the Changes, whose documents are edited in
+// the order of their URIs. FailedChange is the index of the failed change in that order. The Character of every
+// Position counts code units of enc; an empty enc counts UTF-16 code units. The versions of text document edits are
+// not checked, as an FS doesn't keep track of them.
+//
+// failureHandling selects what happens to the changes executed before the one that failed; an empty
+// failureHandling aborts:
+//
+//   - FailureHandlingKindAbort keeps them.
+//   - FailureHandlingKindTransactional executes every change against an in-memory overlay of fsys first and only
+//     writes to fsys once all of them succeeded. Should writing fail, the files written so far are restored.
+//   - FailureHandlingKindTextOnlyTransactional is transactional if edit only contains text document edits and
+//     aborts otherwise.
+//   - FailureHandlingKindUndo restores the files they modified.
+//
+// A nil edit changes nothing and is reported as applied.
+func Apply(fsys FS, edit *protocol.WorkspaceEdit, failureHandling protocol.FailureHandlingKind, enc protocol.PositionEncodingKind) *protocol.ApplyWorkspaceEditResponse {
+	if edit == nil {
+		return &protocol.ApplyWorkspaceEditResponse{Applied: true}
+	}
+	changes, textOnly := compile(edit, enc)
+
+	switch failureHandling {
+	case protocol.FailureHandlingKindTransactional:
+		return applyTransactional(fsys, changes)
+	case protocol.FailureHandlingKindTextOnlyTransactional:
+		if textOnly {
+			return applyTransactional(fsys, changes)
+		}
+		return applyAbort(fsys, changes)
+	case protocol.FailureHandlingKindUndo:
+		return applyUndo(fsys, changes)
+	default:
+		return applyAbort(fsys, changes)
+	}
+}
+
+// applyAbort executes changes against fsys and stops at the first failure.
+func applyAbort(fsys FS, changes []change) *protocol.ApplyWorkspaceEditResponse {
+	for i, c := range changes {
+		if err := c(fsys); err != nil {
+			return failed(i, err)
+		}
+	}
+
+	return &protocol.ApplyWorkspaceEditResponse{Applied: true}
+}
+
+// applyUndo executes changes against fsys and restores the modified files on failure.
+func applyUndo(fsys FS, changes []change) *protocol.ApplyWorkspaceEditResponse {
+	j := newJournal(fsys)
+	for i, c := range changes {
+		if err := c(j); err != nil {
+			if undoErr := j.rollback(); undoErr != nil {
+				err = fmt.Errorf("%w (undo failed: %v)", err, undoErr)
+			}
+			return failed(i, err)
+		}
+	}
+
+	return &protocol.ApplyWorkspaceEditResponse{Applied: true}
+}
+
+// applyTransactional executes changes against an overlay of fsys and writes the result to fsys if all of them
+// succeeded.
+func applyTransactional(fsys FS, changes []change) *protocol.ApplyWorkspaceEditResponse {
+	o := newOverlay(fsys)
+	for i, c := range changes {
+		o.change = i
+		if err := c(o); err != nil {
+			return failed(i, err)
+		}
+	}
+
+	j := newJournal(fsys)
+	if i, err := o.commit(j); err != nil {
+		if undoErr := j.rollback(); undoErr != nil {
+			err = fmt.Errorf("%w (undo failed: %v)", err, undoErr)
+		}
+		return failed(i, err)
+	}
+
+	return &protocol.ApplyWorkspaceEditResponse{Applied: true}
+}
+
+// failed returns the response to a failure of the change at index i.
+func failed(i int, err error) *protocol.ApplyWorkspaceEditResponse {
+	failedChange := uint32(i)
+
+	return &protocol.ApplyWorkspaceEditResponse{
+		Applied:       false,
+		FailureReason: err.Error(),
+		FailedChange:  &failedChange,
+	}
+}
+
+// compile returns the changes of edit in the order they are executed, and whether they are all text edits.
+func compile(edit *protocol.WorkspaceEdit, enc protocol.PositionEncodingKind) (changes []change, textOnly bool) {
+	if edit.DocumentChanges != nil {
+		textOnly = true
+		for i, dc := range edit.DocumentChanges {
+			c := compileDocumentChange(dc, enc)
+			if dc.TextDocumentEdit == nil {
+				textOnly = false
+			}
+			changes = append(changes, wrap(fmt.Sprintf("documentChanges[%d]", i), c))
+		}
+		return changes, textOnly
+	}
+
+	uris := make([]protocol.DocumentURI, 0, len(edit.Changes))
+	for uri := range edit.Changes {
+		uris = append(uris, uri)
+	}
+	sort.Slice(uris, func(i, j int) bool { return uris[i] < uris[j] })
+	for _, uri := range uris {
+		changes = append(changes, wrap(fmt.Sprintf("changes[%s]", uri), editText(uri, edit.Changes[uri], enc)))
+	}
+
+	return changes, true
+}
+
+// wrap prefixes the errors of c with name.
+func wrap(name string, c change) change {
+	return func(fsys FS) error {
+		if err := c(fsys); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return nil
+	}
+}
+
+// compileDocumentChange returns the change executing dc.
+func compileDocumentChange(dc protocol.DocumentChange, enc protocol.PositionEncodingKind) change {
+	switch {
+	case dc.TextDocumentEdit != nil:
+		return editText(dc.TextDocumentEdit.TextDocument.URI, dc.TextDocumentEdit.TextEdits(), enc)
+	case dc.CreateFile != nil:
+		op := *dc.CreateFile
+		return func(fsys FS) error { return createFile(fsys, op) }
+	case dc.RenameFile != nil:
+		op := *dc.RenameFile
+		return func(fsys FS) error { return renameFile(fsys, op) }
+	case dc.DeleteFile != nil:
+		op := *dc.DeleteFile
+		return func(fsys FS) error { return deleteFile(fsys, op) }
+	default:
+		return func(FS) error { return errors.New("empty document change") }
+	}
+}
+
+// editText returns the change applying edits to the file uri.
+func editText(uri protocol.DocumentURI, edits []protocol.TextEdit, enc protocol.PositionEncodingKind) change {
+	return func(fsys FS) error {
+		data, err := fsys.ReadFile(uri)
+		if err != nil {
+			return err
+		}
+		text, _, err := protocol.ApplyTextEdits(string(data), edits, enc)
+		if err != nil {
+			return err
+		}
+		return fsys.WriteFile(uri, []byte(text))
+	}
+}
+
+// createFile executes op.
+func createFile(fsys FS, op protocol.CreateFile) error {
+	var opts protocol.CreateFileOptions
+	if op.Options != nil {
+		opts = *op.Options
+	}
+
+	file, folder, err := stat(fsys, op.URI)
+	if err != nil {
+		return err
+	}
+	if file || folder {
+		switch {
+		case opts.Overwrite:
+			if err := removeAll(fsys, op.URI, file); err != nil {
+				return err
+			}
+		case opts.IgnoreIfExists:
+			return nil
+		default:
+			return fmt.Errorf("create %s: %w", op.URI, fs.ErrExist)
+		}
+	}
+
+	return fsys.WriteFile(op.URI, nil)
+}
+
+// renameFile executes op.
+func renameFile(fsys FS, op protocol.RenameFile) error {
+	var opts protocol.RenameFileOptions
+	if op.Options != nil {
+		opts = *op.Options
+	}
+
+	file, folder, err := stat(fsys, op.OldURI)
+	if err != nil {
+		return err
+	}
+	if !file && !folder {
+		return fmt.Errorf("rename %s: %w", op.OldURI, fs.ErrNotExist)
+	}
+	if op.OldURI == op.NewURI {
+		return nil
+	}
+	if folder && strings.HasPrefix(string(op.NewURI), folderPrefix(op.OldURI)) {
+		return fmt.Errorf("rename %s: cannot move a folder into itself: %s", op.OldURI, op.NewURI)
+	}
+	if strings.HasPrefix(string(op.OldURI), folderPrefix(op.NewURI)) {
+		return fmt.Errorf("rename %s: cannot move onto an ancestor folder: %s", op.OldURI, op.NewURI)
+	}
+
+	newFile, newFolder, err := stat(fsys, op.NewURI)
+	if err != nil {
+		return err
+	}
+	if newFile || newFolder {
+		switch {
+		case opts.Overwrite:
+			if err := removeAll(fsys, op.NewURI, newFile); err != nil {
+				return err
+			}
+		case opts.IgnoreIfExists:
+			return nil
+		default:
+			return fmt.Errorf("rename %s: %s: %w", op.OldURI, op.NewURI, fs.ErrExist)
+		}
+	}
+
+	if file {
+		return move(fsys, op.OldURI, op.NewURI)
+	}
+	files, err := fsys.Files(op.OldURI)
+	if err != nil {
+		return err
+	}
+	oldPrefix, newPrefix := folderPrefix(op.OldURI), folderPrefix(op.NewURI)
+	for _, f := range files {
+		to := protocol.DocumentURI(newPrefix + strings.TrimPrefix(string(f), oldPrefix))
+		if err := move(fsys, f, to); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteFile executes op.
+func deleteFile(fsys FS, op protocol.DeleteFile) error {
+	var opts protocol.DeleteFileOptions
+	if op.Options != nil {
+		opts = *op.Options
+	}
+
+	file, folder, err := stat(fsys, op.URI)
+	if err != nil {
+		return err
+	}
+	switch {
+	case !file && !folder:
+		if opts.IgnoreIfNotExists {
+			return nil
+		}
+		return fmt.Errorf("delete %s: %w", op.URI, fs.ErrNotExist)
+	case folder && !opts.Recursive:
+		return fmt.Errorf("delete %s: folder is not empty", op.URI)
+	}
+
+	return removeAll(fsys, op.URI, file)
+}
+
+// stat reports whether uri is a file or a folder of fsys.
+func stat(fsys FS, uri protocol.DocumentURI) (file, folder bool, err error) {
+	_, err = fsys.ReadFile(uri)
+	switch {
+	case err == nil:
+		return true, false, nil
+	case !errors.Is(err, fs.ErrNotExist):
+		return false, false, err
+	}
+
+	files, err := fsys.Files(uri)
+	if err != nil {
+		return false, false, err
+	}
+
+	return false, len(files) > 0, nil
+}
+
+// removeAll removes the file uri or, if file is false, every file of the folder uri.
+func removeAll(fsys FS, uri protocol.DocumentURI, file bool) error {
+	if file {
+		return fsys.Remove(uri)
+	}
+
+	files, err := fsys.Files(uri)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := fsys.Remove(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// move moves the file from to the file to.
+func move(fsys FS, from, to protocol.DocumentURI) error {
+	data, err := fsys.ReadFile(from)
+	if err != nil {
+		return err
+	}
+	if err := fsys.WriteFile(to, data); err != nil {
+		return err
+	}
+
+	return fsys.Remove(from)
+}
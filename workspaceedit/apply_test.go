@@ -0,0 +1,481 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package workspaceedit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"go.lsp.dev/protocol"
+)
+
+func newTextEdit(line, startChar, endChar uint32, newText string) protocol.TextEdit {
+	return protocol.TextEdit{
+		Range: protocol.Range{
+			Start: protocol.Position{Line: line, Character: startChar},
+			End:   protocol.Position{Line: line, Character: endChar},
+		},
+		NewText: newText,
+	}
+}
+
+func newTextDocumentEdit(uri protocol.DocumentURI, edits ...protocol.TextEdit) protocol.DocumentChange {
+	items := make([]protocol.TextDocumentEditItem, len(edits))
+	for i := range edits {
+		items[i] = protocol.TextDocumentEditItem{TextEdit: &edits[i]}
+	}
+
+	return protocol.DocumentChange{
+		TextDocumentEdit: &protocol.TextDocumentEdit{
+			TextDocument: protocol.OptionalVersionedTextDocumentIdentifier{
+				TextDocumentIdentifier: protocol.TextDocumentIdentifier{URI: uri},
+			},
+			Edits: items,
+		},
+	}
+}
+
+func newCreateFile(uri protocol.DocumentURI, opts *protocol.CreateFileOptions) protocol.DocumentChange {
+	return protocol.DocumentChange{CreateFile: &protocol.CreateFile{URI: uri, Options: opts}}
+}
+
+func newRenameFile(oldURI, newURI protocol.DocumentURI, opts *protocol.RenameFileOptions) protocol.DocumentChange {
+	return protocol.DocumentChange{RenameFile: &protocol.RenameFile{OldURI: oldURI, NewURI: newURI, Options: opts}}
+}
+
+func newDeleteFile(uri protocol.DocumentURI, opts *protocol.DeleteFileOptions) protocol.DocumentChange {
+	return protocol.DocumentChange{DeleteFile: &protocol.DeleteFile{URI: uri, Options: opts}}
+}
+
+func newFailedChange(i uint32) *uint32 {
+	return &i
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	files := map[protocol.DocumentURI]string{
+		"file:///a.go":       "package a\n",
+		"file:///b.go":       "package b\n",
+		"file:///dir/c.go":   "package dir\n",
+		"file:///dir/x/d.go": "package x\n",
+	}
+
+	tests := []struct {
+		name       string
+		edit       protocol.WorkspaceEdit
+		want       map[protocol.DocumentURI]string
+		wantFailed *uint32
+	}{
+		{
+			name: "Changes",
+			edit: protocol.WorkspaceEdit{
+				Changes: map[protocol.DocumentURI][]protocol.TextEdit{
+					"file:///a.go": {newTextEdit(0, 8, 9, "aa")},
+					"file:///b.go": {newTextEdit(0, 0, 0, "// b\n")},
+				},
+			},
+			want: map[protocol.DocumentURI]string{
+				"file:///a.go":       "package aa\n",
+				"file:///b.go":       "// b\npackage b\n",
+				"file:///dir/c.go":   "package dir\n",
+				"file:///dir/x/d.go": "package x\n",
+			},
+		},
+		{
+			name: "ChangesMissingFile",
+			edit: protocol.WorkspaceEdit{
+				Changes: map[protocol.DocumentURI][]protocol.TextEdit{
+					"file:///a.go":       {newTextEdit(0, 8, 9, "aa")},
+					"file:///missing.go": {newTextEdit(0, 0, 0, "x")},
+				},
+			},
+			want: map[protocol.DocumentURI]string{
+				"file:///a.go":       "package aa\n",
+				"file:///b.go":       "package b\n",
+				"file:///dir/c.go":   "package dir\n",
+				"file:///dir/x/d.go": "package x\n",
+			},
+			wantFailed: newFailedChange(1),
+		},
+		{
+			name: "DocumentChanges",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{
+					newCreateFile("file:///e.go", nil),
+					newTextDocumentEdit("file:///e.go", newTextEdit(0, 0, 0, "package e\n")),
+					newRenameFile("file:///e.go", "file:///f.go", nil),
+					newDeleteFile("file:///b.go", nil),
+				},
+			},
+			want: map[protocol.DocumentURI]string{
+				"file:///a.go":       "package a\n",
+				"file:///f.go":       "package e\n",
+				"file:///dir/c.go":   "package dir\n",
+				"file:///dir/x/d.go": "package x\n",
+			},
+		},
+		{
+			name: "DocumentChangesTakePrecedence",
+			edit: protocol.WorkspaceEdit{
+				Changes: map[protocol.DocumentURI][]protocol.TextEdit{
+					"file:///a.go": {newTextEdit(0, 8, 9, "aa")},
+				},
+				DocumentChanges: []protocol.DocumentChange{
+					newTextDocumentEdit("file:///b.go", newTextEdit(0, 8, 9, "bb")),
+				},
+			},
+			want: map[protocol.DocumentURI]string{
+				"file:///a.go":       "package a\n",
+				"file:///b.go":       "package bb\n",
+				"file:///dir/c.go":   "package dir\n",
+				"file:///dir/x/d.go": "package x\n",
+			},
+		},
+		{
+			name: "CreateExisting",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{newCreateFile("file:///a.go", nil)},
+			},
+			want:       files,
+			wantFailed: newFailedChange(0),
+		},
+		{
+			name: "CreateIgnoreIfExists",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{
+					newCreateFile("file:///a.go", &protocol.CreateFileOptions{IgnoreIfExists: true}),
+				},
+			},
+			want: files,
+		},
+		{
+			name: "CreateOverwrite",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{
+					newCreateFile("file:///a.go", &protocol.CreateFileOptions{Overwrite: true, IgnoreIfExists: true}),
+					newCreateFile("file:///dir", &protocol.CreateFileOptions{Overwrite: true}),
+				},
+			},
+			want: map[protocol.DocumentURI]string{
+				"file:///a.go": "",
+				"file:///b.go": "package b\n",
+				"file:///dir":  "",
+			},
+		},
+		{
+			name: "RenameMissing",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{newRenameFile("file:///missing.go", "file:///e.go", nil)},
+			},
+			want:       files,
+			wantFailed: newFailedChange(0),
+		},
+		{
+			name: "RenameExisting",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{newRenameFile("file:///a.go", "file:///b.go", nil)},
+			},
+			want:       files,
+			wantFailed: newFailedChange(0),
+		},
+		{
+			name: "RenameIgnoreIfExists",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{
+					newRenameFile("file:///a.go", "file:///b.go", &protocol.RenameFileOptions{IgnoreIfExists: true}),
+				},
+			},
+			want: files,
+		},
+		{
+			name: "RenameOverwrite",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{
+					newRenameFile("file:///a.go", "file:///b.go", &protocol.RenameFileOptions{Overwrite: true}),
+				},
+			},
+			want: map[protocol.DocumentURI]string{
+				"file:///b.go":       "package a\n",
+				"file:///dir/c.go":   "package dir\n",
+				"file:///dir/x/d.go": "package x\n",
+			},
+		},
+		{
+			name: "RenameFolder",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{newRenameFile("file:///dir", "file:///pkg/", nil)},
+			},
+			want: map[protocol.DocumentURI]string{
+				"file:///a.go":       "package a\n",
+				"file:///b.go":       "package b\n",
+				"file:///pkg/c.go":   "package dir\n",
+				"file:///pkg/x/d.go": "package x\n",
+			},
+		},
+		{
+			name: "RenameFolderIntoItself",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{newRenameFile("file:///dir", "file:///dir/y", nil)},
+			},
+			want:       files,
+			wantFailed: newFailedChange(0),
+		},
+		{
+			name: "RenameFolderOntoAncestor",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{
+					newRenameFile("file:///dir/x", "file:///dir", &protocol.RenameFileOptions{Overwrite: true}),
+				},
+			},
+			want:       files,
+			wantFailed: newFailedChange(0),
+		},
+		{
+			name: "RenameFileOntoAncestor",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{
+					newRenameFile("file:///dir/c.go", "file:///dir/", &protocol.RenameFileOptions{Overwrite: true}),
+				},
+			},
+			want:       files,
+			wantFailed: newFailedChange(0),
+		},
+		{
+			name: "DeleteMissing",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{newDeleteFile("file:///missing.go", nil)},
+			},
+			want:       files,
+			wantFailed: newFailedChange(0),
+		},
+		{
+			name: "DeleteIgnoreIfNotExists",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{
+					newDeleteFile("file:///missing.go", &protocol.DeleteFileOptions{IgnoreIfNotExists: true}),
+				},
+			},
+			want: files,
+		},
+		{
+			name: "DeleteFolder",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{newDeleteFile("file:///dir", nil)},
+			},
+			want:       files,
+			wantFailed: newFailedChange(0),
+		},
+		{
+			name: "DeleteFolderRecursive",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{
+					newDeleteFile("file:///dir", &protocol.DeleteFileOptions{Recursive: true}),
+				},
+			},
+			want: map[protocol.DocumentURI]string{
+				"file:///a.go": "package a\n",
+				"file:///b.go": "package b\n",
+			},
+		},
+		{
+			name: "OverlappingTextEdits",
+			edit: protocol.WorkspaceEdit{
+				DocumentChanges: []protocol.DocumentChange{
+					newTextDocumentEdit("file:///a.go", newTextEdit(0, 0, 5, "x"), newTextEdit(0, 3, 7, "y")),
+				},
+			},
+			want:       files,
+			wantFailed: newFailedChange(0),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fsys := NewMemFS(files)
+			got := Apply(fsys, &tt.edit, protocol.FailureHandlingKindAbort, protocol.PositionEncodingKindUTF16)
+			if got.Applied != (tt.wantFailed == nil) {
+				t.Errorf("Applied = %t: %s", got.Applied, got.FailureReason)
+			}
+			if diff := cmp.Diff(tt.wantFailed, got.FailedChange); diff != "" {
+				t.Errorf("FailedChange: (-want +got)\n%s", diff)
+			}
+			if got.Applied != (got.FailureReason == "") {
+				t.Errorf("FailureReason = %q", got.FailureReason)
+			}
+			if diff := cmp.Diff(tt.want, fsys.Snapshot()); diff != "" {
+				t.Errorf("files: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApply_FailureHandling(t *testing.T) {
+	t.Parallel()
+
+	files := map[protocol.DocumentURI]string{
+		"file:///a.go": "package a\n",
+		"file:///b.go": "package b\n",
+	}
+	mixed := protocol.WorkspaceEdit{
+		DocumentChanges: []protocol.DocumentChange{
+			newTextDocumentEdit("file:///a.go", newTextEdit(0, 8, 9, "aa")),
+			newRenameFile("file:///b.go", "file:///c.go", nil),
+			newTextDocumentEdit("file:///missing.go", newTextEdit(0, 0, 0, "x")),
+		},
+	}
+	textOnly := protocol.WorkspaceEdit{
+		DocumentChanges: []protocol.DocumentChange{
+			newTextDocumentEdit("file:///a.go", newTextEdit(0, 8, 9, "aa")),
+			newTextDocumentEdit("file:///b.go", newTextEdit(0, 8, 9, "bb")),
+			newTextDocumentEdit("file:///missing.go", newTextEdit(0, 0, 0, "x")),
+		},
+	}
+	partial := map[protocol.DocumentURI]string{
+		"file:///a.go": "package aa\n",
+		"file:///c.go": "package b\n",
+	}
+
+	tests := []struct {
+		name            string
+		edit            protocol.WorkspaceEdit
+		failureHandling protocol.FailureHandlingKind
+		want            map[protocol.DocumentURI]string
+	}{
+		{
+			name:            "Default",
+			edit:            mixed,
+			failureHandling: "",
+			want:            partial,
+		},
+		{
+			name:            "Abort",
+			edit:            mixed,
+			failureHandling: protocol.FailureHandlingKindAbort,
+			want:            partial,
+		},
+		{
+			name:            "Transactional",
+			edit:            mixed,
+			failureHandling: protocol.FailureHandlingKindTransactional,
+			want:            files,
+		},
+		{
+			name:            "TextOnlyTransactional",
+			edit:            textOnly,
+			failureHandling: protocol.FailureHandlingKindTextOnlyTransactional,
+			want:            files,
+		},
+		{
+			name:            "TextOnlyTransactionalWithResourceOperations",
+			edit:            mixed,
+			failureHandling: protocol.FailureHandlingKindTextOnlyTransactional,
+			want:            partial,
+		},
+		{
+			name:            "Undo",
+			edit:            mixed,
+			failureHandling: protocol.FailureHandlingKindUndo,
+			want:            files,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fsys := NewMemFS(files)
+			got := Apply(fsys, &tt.edit, tt.failureHandling, protocol.PositionEncodingKindUTF16)
+			want := &protocol.ApplyWorkspaceEditResponse{
+				Applied:       false,
+				FailureReason: "documentChanges[2]: read file:///missing.go: file does not exist",
+				FailedChange:  newFailedChange(2),
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("(-want +got)\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.want, fsys.Snapshot()); diff != "" {
+				t.Errorf("files: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+// failingFS is a MemFS that fails to write the file uri.
+type failingFS struct {
+	*MemFS
+	uri protocol.DocumentURI
+}
+
+var errWrite = errors.New("write failed")
+
+func (f *failingFS) WriteFile(uri protocol.DocumentURI, data []byte) error {
+	if uri == f.uri {
+		return errWrite
+	}
+
+	return f.MemFS.WriteFile(uri, data)
+}
+
+func TestApply_TransactionalCommit(t *testing.T) {
+	t.Parallel()
+
+	files := map[protocol.DocumentURI]string{
+		"file:///a.go": "package a\n",
+		"file:///b.go": "package b\n",
+	}
+	edit := protocol.WorkspaceEdit{
+		DocumentChanges: []protocol.DocumentChange{
+			newTextDocumentEdit("file:///a.go", newTextEdit(0, 8, 9, "aa")),
+			newDeleteFile("file:///b.go", nil),
+			newCreateFile("file:///c.go", nil),
+			newTextDocumentEdit("file:///c.go", newTextEdit(0, 0, 0, "package c\n")),
+		},
+	}
+
+	for _, failureHandling := range []protocol.FailureHandlingKind{
+		protocol.FailureHandlingKindTransactional,
+		protocol.FailureHandlingKindUndo,
+	} {
+		fsys := &failingFS{MemFS: NewMemFS(files), uri: "file:///c.go"}
+		got := Apply(fsys, &edit, failureHandling, protocol.PositionEncodingKindUTF16)
+		if got.Applied || got.FailedChange == nil || *got.FailedChange < 2 {
+			t.Errorf("%s: Apply() = %+v", failureHandling, got)
+		}
+		if diff := cmp.Diff(files, fsys.Snapshot()); diff != "" {
+			t.Errorf("%s: files: (-want +got)\n%s", failureHandling, diff)
+		}
+	}
+
+	fsys := NewMemFS(files)
+	got := Apply(fsys, &edit, protocol.FailureHandlingKindTransactional, protocol.PositionEncodingKindUTF16)
+	if diff := cmp.Diff(&protocol.ApplyWorkspaceEditResponse{Applied: true}, got); diff != "" {
+		t.Errorf("(-want +got)\n%s", diff)
+	}
+	want := map[protocol.DocumentURI]string{
+		"file:///a.go": "package aa\n",
+		"file:///c.go": "package c\n",
+	}
+	if diff := cmp.Diff(want, fsys.Snapshot()); diff != "" {
+		t.Errorf("files: (-want +got)\n%s", diff)
+	}
+}
+
+func TestApply_NilEdit(t *testing.T) {
+	t.Parallel()
+
+	files := map[protocol.DocumentURI]string{"file:///a.go": "package a\n"}
+	fsys := NewMemFS(files)
+	got := Apply(fsys, nil, protocol.FailureHandlingKindTransactional, protocol.PositionEncodingKindUTF16)
+	if diff := cmp.Diff(&protocol.ApplyWorkspaceEditResponse{Applied: true}, got); diff != "" {
+		t.Errorf("(-want +got)\n%s", diff)
+	}
+	if diff := cmp.Diff(files, fsys.Snapshot()); diff != "" {
+		t.Errorf("files: (-want +got)\n%s", diff)
+	}
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package workspaceedit
+
+import (
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.lsp.dev/protocol"
+)
+
+// FS is the file system a WorkspaceEdit is applied to.
+//
+// An FS holds files only. A folder exists as long as a file exists below it, so creating a file creates its parent
+// folders and removing the last file of a folder removes the folder.
+type FS interface {
+	// ReadFile returns the content of the file uri.
+	//
+	// It returns an error wrapping fs.ErrNotExist if there is no such file.
+	ReadFile(uri protocol.DocumentURI) ([]byte, error)
+
+	// WriteFile creates or truncates the file uri and writes data to it.
+	WriteFile(uri protocol.DocumentURI, data []byte) error
+
+	// Remove removes the file uri.
+	//
+	// It returns an error wrapping fs.ErrNotExist if there is no such file.
+	Remove(uri protocol.DocumentURI) error
+
+	// Files returns the files below the folder uri, at any depth, sorted by URI.
+	//
+	// It returns no files if the folder doesn't exist.
+	Files(uri protocol.DocumentURI) ([]protocol.DocumentURI, error)
+}
+
+// folderPrefix returns the prefix of the URIs of the files below the folder uri.
+func folderPrefix(uri protocol.DocumentURI) string {
+	return strings.TrimSuffix(string(uri), "/") + "/"
+}
+
+// MemFS is an in-memory FS.
+//
+// A MemFS is safe for concurrent use. The zero value is an empty MemFS ready to use.
+type MemFS struct {
+	mu    sync.RWMutex
+	files map[protocol.DocumentURI][]byte
+}
+
+// compile time check whether the MemFS implements a FS interface.
+var _ FS = (*MemFS)(nil)
+
+// NewMemFS returns a MemFS holding files, keyed by URI.
+func NewMemFS(files map[protocol.DocumentURI]string) *MemFS {
+	m := &MemFS{
+		files: make(map[protocol.DocumentURI][]byte, len(files)),
+	}
+	for uri, text := range files {
+		m.files[uri] = []byte(text)
+	}
+
+	return m
+}
+
+// ReadFile implements FS.
+func (m *MemFS) ReadFile(uri protocol.DocumentURI) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data, ok := m.files[uri]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: string(uri), Err: fs.ErrNotExist}
+	}
+
+	return append([]byte(nil), data...), nil
+}
+
+// WriteFile implements FS.
+func (m *MemFS) WriteFile(uri protocol.DocumentURI, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.files == nil {
+		m.files = make(map[protocol.DocumentURI][]byte)
+	}
+	m.files[uri] = append([]byte(nil), data...)
+
+	return nil
+}
+
+// Remove implements FS.
+func (m *MemFS) Remove(uri protocol.DocumentURI) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.files[uri]; !ok {
+		return &fs.PathError{Op: "remove", Path: string(uri), Err: fs.ErrNotExist}
+	}
+	delete(m.files, uri)
+
+	return nil
+}
+
+// Files implements FS.
+func (m *MemFS) Files(uri protocol.DocumentURI) ([]protocol.DocumentURI, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	prefix := folderPrefix(uri)
+	var files []protocol.DocumentURI
+	for file := range m.files {
+		if strings.HasPrefix(string(file), prefix) {
+			files = append(files, file)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i] < files[j] })
+
+	return files, nil
+}
+
+// Snapshot returns the text of every file, keyed by URI.
+func (m *MemFS) Snapshot() map[protocol.DocumentURI]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	files := make(map[protocol.DocumentURI]string, len(m.files))
+	for uri, data := range m.files {
+		files[uri] = string(data)
+	}
+
+	return files
+}
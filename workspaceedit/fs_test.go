@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package workspaceedit
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"go.lsp.dev/protocol"
+)
+
+func TestMemFS(t *testing.T) {
+	t.Parallel()
+
+	var m MemFS
+	if _, err := m.ReadFile("file:///a.go"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadFile() error = %v, want %v", err, fs.ErrNotExist)
+	}
+	if err := m.Remove("file:///a.go"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Remove() error = %v, want %v", err, fs.ErrNotExist)
+	}
+
+	for _, uri := range []protocol.DocumentURI{"file:///dir/b.go", "file:///dir/x/c.go", "file:///dirx/d.go", "file:///dir"} {
+		if err := m.WriteFile(uri, []byte(uri)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := m.ReadFile("file:///dir/b.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[0] = 'x'
+	if data, _ := m.ReadFile("file:///dir/b.go"); string(data) != "file:///dir/b.go" {
+		t.Errorf("ReadFile() = %q, must not share memory with the MemFS", data)
+	}
+
+	for _, uri := range []protocol.DocumentURI{"file:///dir", "file:///dir/"} {
+		files, err := m.Files(uri)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []protocol.DocumentURI{"file:///dir/b.go", "file:///dir/x/c.go"}
+		if diff := cmp.Diff(want, files); diff != "" {
+			t.Errorf("Files(%q): (-want +got)\n%s", uri, diff)
+		}
+	}
+
+	if err := m.Remove("file:///dir/x/c.go"); err != nil {
+		t.Fatal(err)
+	}
+	if files, _ := m.Files("file:///dir/x"); len(files) != 0 {
+		t.Errorf("Files() = %v, want none", files)
+	}
+}
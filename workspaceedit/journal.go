@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2022 The Go Language Server Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package workspaceedit
+
+import (
+	"errors"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"go.lsp.dev/protocol"
+)
+
+// savedFile is the state of a file before it was first modified.
+type savedFile struct {
+	data    []byte
+	existed bool
+}
+
+// journal is an FS that records the state of every file before modifying it, so that it can be restored.
+type journal struct {
+	FS
+
+	saved map[protocol.DocumentURI]savedFile
+	order []protocol.DocumentURI
+}
+
+// newJournal returns a journal of fsys.
+func newJournal(fsys FS) *journal {
+	return &journal{
+		FS:    fsys,
+		saved: make(map[protocol.DocumentURI]savedFile),
+	}
+}
+
+// WriteFile implements FS.
+func (j *journal) WriteFile(uri protocol.DocumentURI, data []byte) error {
+	if err := j.save(uri); err != nil {
+		return err
+	}
+
+	return j.FS.WriteFile(uri, data)
+}
+
+// Remove implements FS.
+func (j *journal) Remove(uri protocol.DocumentURI) error {
+	if err := j.save(uri); err != nil {
+		return err
+	}
+
+	return j.FS.Remove(uri)
+}
+
+// save records the state of uri unless it was recorded already.
+func (j *journal) save(uri protocol.DocumentURI) error {
+	if _, ok := j.saved[uri]; ok {
+		return nil
+	}
+
+	data, err := j.FS.ReadFile(uri)
+	switch {
+	case err == nil:
+		j.saved[uri] = savedFile{data: data, existed: true}
+	case errors.Is(err, fs.ErrNotExist):
+		j.saved[uri] = savedFile{}
+	default:
+		return err
+	}
+	j.order = append(j.order, uri)
+
+	return nil
+}
+
+// rollback restores the recorded files in reverse order. It carries on after a failure and returns the first error.
+func (j *journal) rollback() error {
+	var firstErr error
+	for i := len(j.order) - 1; i >= 0; i-- {
+		uri := j.order[i]
+		var err error
+		if s := j.saved[uri]; s.existed {
+			err = j.FS.WriteFile(uri, s.data)
+		} else if err = j.FS.Remove(uri); errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// overlay is an FS that keeps every modification of its base in memory until it is committed.
+type overlay struct {
+	base FS
+
+	// files holds the modified files, nil for a removed file.
+	files map[protocol.DocumentURI][]byte
+	order []protocol.DocumentURI
+
+	// change is the index of the change being executed, and changes the index of the last change that modified
+	// each file.
+	change  int
+	changes map[protocol.DocumentURI]int
+}
+
+// newOverlay returns an empty overlay of base.
+func newOverlay(base FS) *overlay {
+	return &overlay{
+		base:    base,
+		files:   make(map[protocol.DocumentURI][]byte),
+		changes: make(map[protocol.DocumentURI]int),
+	}
+}
+
+// ReadFile implements FS.
+func (o *overlay) ReadFile(uri protocol.DocumentURI) ([]byte, error) {
+	data, ok := o.files[uri]
+	switch {
+	case !ok:
+		return o.base.ReadFile(uri)
+	case data == nil:
+		return nil, &fs.PathError{Op: "read", Path: string(uri), Err: fs.ErrNotExist}
+	default:
+		return append([]byte(nil), data...), nil
+	}
+}
+
+// WriteFile implements FS.
+func (o *overlay) WriteFile(uri protocol.DocumentURI, data []byte) error {
+	o.touch(uri)
+	o.files[uri] = append([]byte{}, data...)
+
+	return nil
+}
+
+// Remove implements FS.
+func (o *overlay) Remove(uri protocol.DocumentURI) error {
+	if _, err := o.ReadFile(uri); err != nil {
+		return err
+	}
+	o.touch(uri)
+	o.files[uri] = nil
+
+	return nil
+}
+
+// Files implements FS.
+func (o *overlay) Files(uri protocol.DocumentURI) ([]protocol.DocumentURI, error) {
+	base, err := o.base.Files(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []protocol.DocumentURI
+	for _, f := range base {
+		if _, ok := o.files[f]; !ok {
+			files = append(files, f)
+		}
+	}
+	prefix := folderPrefix(uri)
+	for f, data := range o.files {
+		if data != nil && strings.HasPrefix(string(f), prefix) {
+			files = append(files, f)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i] < files[j] })
+
+	return files, nil
+}
+
+// touch records that the current change modifies uri.
+func (o *overlay) touch(uri protocol.DocumentURI) {
+	if _, ok := o.files[uri]; !ok {
+		o.order = append(o.order, uri)
+	}
+	o.changes[uri] = o.change
+}
+
+// commit writes the modified files to fsys in the order they were first modified. On failure it returns the index
+// of the last change that modified the file it failed to write.
+func (o *overlay) commit(fsys FS) (int, error) {
+	for _, uri := range o.order {
+		data := o.files[uri]
+		var err error
+		if data != nil {
+			err = fsys.WriteFile(uri, data)
+		} else if err = fsys.Remove(uri); errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+		if err != nil {
+			return o.changes[uri], err
+		}
+	}
+
+	return 0, nil
+}